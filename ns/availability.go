@@ -1,6 +1,7 @@
 package ns
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -25,8 +26,13 @@ type FreeYachtRequest struct {
 // AvailabilityService operates over availability requests.
 type AvailabilityService service
 
-// GetAvailability returns availability for the specified yachts.
+// GetAvailability returns availability for the specified yachts. Large
+// requests are split into chunks, see GetAvailabilityContext.
 func (as *AvailabilityService) GetAvailability(arq *FreeYachtRequest) (ar *FreeYachtListResponse, err error) {
+	return as.GetAvailabilityContext(context.Background(), arq)
+}
+
+func (as *AvailabilityService) freeYachts(ctx context.Context, arq *FreeYachtRequest) (ar *FreeYachtListResponse, err error) {
 	arq.Credentials = &Credentials{
 		Username: os.Getenv(APIUsernameContainer),
 		Password: os.Getenv(APIPasswordContainer),
@@ -39,7 +45,7 @@ func (as *AvailabilityService) GetAvailability(arq *FreeYachtRequest) (ar *FreeY
 		return
 	}
 
-	res, err := as.client.Do(req)
+	res, err := as.client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
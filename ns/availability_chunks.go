@@ -0,0 +1,211 @@
+package ns
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// Chunk sizes used by GetAvailability to split large requests.
+const (
+	AvailabilityYachtsPerRequest = 200
+	DefaultPeriodsPerRequest     = 7
+)
+
+// Values of the OrderBy and Direction fields of a FreeYachtRequest.
+const (
+	OrderByPrice        = 1
+	DirectionAscending  = 0
+	DirectionDescending = 1
+)
+
+type freeYachtOrder struct {
+	orderBy   int
+	direction int
+}
+
+// freeYachtOrderings holds the comparators used to merge the results of a
+// chunked availability request in the order requested from Nausys.
+var freeYachtOrderings = map[freeYachtOrder]func(a, b *FreeYacht) bool{
+	{OrderByPrice, DirectionAscending}: func(a, b *FreeYacht) bool {
+		return comparePrices(a, b) < 0
+	},
+	{OrderByPrice, DirectionDescending}: func(a, b *FreeYacht) bool {
+		return comparePrices(a, b) > 0
+	},
+}
+
+// GetAvailabilityContext returns availability for the specified yachts.
+//
+// Requests with more than AvailabilityYachtsPerRequest yachts or more than
+// DefaultPeriodsPerRequest periods are split into chunks sent concurrently,
+// through the client rate limit. The responses are merged into one, the
+// first failing chunk failing the whole request. A single PeriodFrom and
+// PeriodTo pair is one charter and is never split.
+//
+// Merged free yachts are ordered by price when requested with OrderBy and
+// Direction, other orderings keep the chunk order, see
+// GetAvailabilityOrdered.
+func (as *AvailabilityService) GetAvailabilityContext(ctx context.Context, arq *FreeYachtRequest) (*FreeYachtListResponse, error) {
+	return as.GetAvailabilityOrdered(ctx, arq, nil)
+}
+
+// GetAvailabilityOrdered works like GetAvailabilityContext, merging chunked
+// responses in the order given by less, which reports whether a comes
+// before b. The built-in ordering for the request is used when less is nil.
+func (as *AvailabilityService) GetAvailabilityOrdered(ctx context.Context, arq *FreeYachtRequest, less func(a, b *FreeYacht) bool) (*FreeYachtListResponse, error) {
+	if less == nil {
+		less = freeYachtOrderings[freeYachtOrder{arq.OrderBy, arq.Direction}]
+	}
+
+	return as.chunkedAvailability(ctx, arq, AvailabilityYachtsPerRequest, DefaultPeriodsPerRequest, less)
+}
+
+func (as *AvailabilityService) chunkedAvailability(ctx context.Context, arq *FreeYachtRequest, yachtsPerRequest, periodsPerRequest int, less func(a, b *FreeYacht) bool) (*FreeYachtListResponse, error) {
+	chunks := splitFreeYachtRequest(arq, yachtsPerRequest, periodsPerRequest)
+	if len(chunks) == 1 {
+		return as.freeYachts(ctx, arq)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		jobs     = make(chan int)
+		ars      = make([]*FreeYachtListResponse, len(chunks))
+	)
+
+	for i := 0; i < DefaultWorkers && i < len(chunks); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				ar, err := as.freeYachts(ctx, chunks[j])
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				ars[j] = ar
+			}
+		}()
+	}
+
+dispatch:
+	for i := range chunks {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return mergeFreeYachtLists(arq, ars, less), nil
+}
+
+// splitFreeYachtRequest splits the request into chunks of at most the given
+// number of yachts and periods. Chunks are ordered by yachts, then periods.
+func splitFreeYachtRequest(arq *FreeYachtRequest, yachtsPerRequest, periodsPerRequest int) []*FreeYachtRequest {
+	if yachtsPerRequest <= 0 {
+		yachtsPerRequest = AvailabilityYachtsPerRequest
+	}
+	if periodsPerRequest <= 0 {
+		periodsPerRequest = DefaultPeriodsPerRequest
+	}
+
+	var chunks []*FreeYachtRequest
+	for _, ids := range chunkIDs(arq.YachtIds, yachtsPerRequest) {
+		for _, ps := range chunkPeriods(arq.Periods, periodsPerRequest) {
+			c := *arq
+			c.YachtIds, c.Periods = ids, ps
+			chunks = append(chunks, &c)
+		}
+	}
+
+	return chunks
+}
+
+func chunkIDs(ids []int64, size int) [][]int64 {
+	if len(ids) <= size {
+		return [][]int64{ids}
+	}
+
+	var chunks [][]int64
+	for i := 0; i < len(ids); i += size {
+		end := i + size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunks = append(chunks, ids[i:end])
+	}
+
+	return chunks
+}
+
+func chunkPeriods(ps []*Period, size int) [][]*Period {
+	if len(ps) <= size {
+		return [][]*Period{ps}
+	}
+
+	var chunks [][]*Period
+	for i := 0; i < len(ps); i += size {
+		end := i + size
+		if end > len(ps) {
+			end = len(ps)
+		}
+		chunks = append(chunks, ps[i:end])
+	}
+
+	return chunks
+}
+
+// mergeFreeYachtLists merges the responses of the chunks of a request,
+// ordering the free yachts with less when it is not nil. The status is the
+// one of the first chunk not reported OK, if any.
+func mergeFreeYachtLists(arq *FreeYachtRequest, ars []*FreeYachtListResponse, less func(a, b *FreeYacht) bool) *FreeYachtListResponse {
+	m := &FreeYachtListResponse{
+		PeriodFrom: arq.PeriodFrom,
+		PeriodTo:   arq.PeriodTo,
+	}
+
+	for _, ar := range ars {
+		if ar == nil {
+			continue
+		}
+
+		if m.Status == "" || m.Status == "OK" && ar.Status != "OK" {
+			m.Status, m.ErrorCode = ar.Status, ar.ErrorCode
+		}
+		if m.PaymentPlans == nil {
+			m.PaymentPlans = ar.PaymentPlans
+		}
+		if m.PeriodFrom == nil {
+			m.PeriodFrom, m.PeriodTo = ar.PeriodFrom, ar.PeriodTo
+		}
+
+		m.FreeYachts = append(m.FreeYachts, ar.FreeYachts...)
+	}
+
+	if less != nil {
+		sort.SliceStable(m.FreeYachts, func(i, j int) bool {
+			return less(&m.FreeYachts[i], &m.FreeYachts[j])
+		})
+	}
+
+	return m
+}
@@ -0,0 +1,122 @@
+package ns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newAvailabilityServer answers free yacht requests with every requested
+// yacht, priced 100 EUR more for each ID below 1000.
+func newAvailabilityServer(t *testing.T, maxYachts int, requests *int32) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		var arq FreeYachtRequest
+		if err := json.NewDecoder(r.Body).Decode(&arq); err != nil || len(arq.YachtIds) > maxYachts {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		atomic.AddInt32(requests, 1)
+
+		var fys []string
+		for _, id := range arq.YachtIds {
+			fys = append(fys, fmt.Sprintf(`{"yachtId":%d,"price":{"clientPrice":%d,"currency":"EUR"}}`, id, 100000-id*100))
+		}
+		fmt.Fprintf(w, `{"status":"OK","freeYachts":[%s]}`, strings.Join(fys, ","))
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	c, _ := NewClient(nil)
+	c.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	return c
+}
+
+func TestAvailabilityService_ChunkedAvailability(t *testing.T) {
+	var requests int32
+	c := newAvailabilityServer(t, 2, &requests)
+
+	arq := &FreeYachtRequest{YachtIds: []int64{1, 2, 3, 4, 5}, OrderBy: 99, Direction: 1}
+
+	ar, err := c.Availability.chunkedAvailability(context.Background(), arq, 2, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+
+	// Without an ordering chunks are merged in request order.
+	if got := freeYachtIDs(ar); got != "[1 2 3 4 5]" {
+		t.Errorf("got %s, want [1 2 3 4 5]", got)
+	}
+
+	byIDDesc := func(a, b *FreeYacht) bool { return a.YachtId > b.YachtId }
+	if ar, err = c.Availability.chunkedAvailability(context.Background(), arq, 2, 0, byIDDesc); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := freeYachtIDs(ar); got != "[5 4 3 2 1]" {
+		t.Errorf("got %s, want [5 4 3 2 1]", got)
+	}
+}
+
+func TestAvailabilityService_GetAvailabilityOrder(t *testing.T) {
+	var requests int32
+	c := newAvailabilityServer(t, AvailabilityYachtsPerRequest, &requests)
+
+	ids := make([]int64, AvailabilityYachtsPerRequest+50)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+
+	tests := []struct {
+		direction int
+		less      func(a, b int64) bool
+	}{
+		// Prices decrease with the yacht ID.
+		{DirectionAscending, func(a, b int64) bool { return a > b }},
+		{DirectionDescending, func(a, b int64) bool { return a < b }},
+	}
+
+	for _, tt := range tests {
+		ar, err := c.Availability.GetAvailability(&FreeYachtRequest{YachtIds: ids, OrderBy: OrderByPrice, Direction: tt.direction})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(ar.FreeYachts) != len(ids) {
+			t.Fatalf("expected %d free yachts, got %d", len(ids), len(ar.FreeYachts))
+		}
+
+		if !sort.SliceIsSorted(ar.FreeYachts, func(i, j int) bool {
+			return tt.less(ar.FreeYachts[i].YachtId, ar.FreeYachts[j].YachtId)
+		}) {
+			t.Errorf("direction %d: free yachts are not ordered by price", tt.direction)
+		}
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 4 {
+		t.Errorf("expected 4 requests, got %d", n)
+	}
+}
+
+func freeYachtIDs(ar *FreeYachtListResponse) string {
+	ids := make([]int64, 0, len(ar.FreeYachts))
+	for _, fy := range ar.FreeYachts {
+		ids = append(ids, fy.YachtId)
+	}
+
+	return fmt.Sprint(ids)
+}
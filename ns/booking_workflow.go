@@ -0,0 +1,165 @@
+package ns
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Default polling settings used by Book while an option waits for approval.
+const (
+	DefaultBookPollInterval = 30 * time.Second
+	DefaultBookPollTimeout  = 15 * time.Minute
+)
+
+// Booking workflow step names.
+const (
+	BookStepInfo     = "info"
+	BookStepOption   = "option"
+	BookStepApproval = "approval"
+	BookStepBooking  = "booking"
+	BookStepRollback = "rollback"
+)
+
+// BookOptions tunes the behaviour of the Book workflow.
+type BookOptions struct {
+	// WaitingOption requests a waiting option when the yacht is already optioned.
+	WaitingOption bool
+	// NeedsOptionApproval waits for the option to be approved before
+	// booking, it should mirror Yacht.NeedsOptionApproval.
+	NeedsOptionApproval bool
+	// OptionOnly stops the workflow once the option is in place.
+	OptionOnly   bool
+	PollInterval time.Duration
+	PollTimeout  time.Duration
+}
+
+// BookStep records the outcome of a single step of the Book workflow.
+type BookStep struct {
+	Name        string
+	At          time.Time
+	Reservation *ReservationInfo
+	Err         error
+}
+
+// BookResult is the step by step outcome of the Book workflow.
+type BookResult struct {
+	Steps []*BookStep
+	// Reservation is the latest state of the reservation.
+	Reservation *ReservationInfo
+	// RolledBack is set when a failure caused the option to be released.
+	RolledBack bool
+}
+
+func (br *BookResult) record(name string, ri *ReservationInfo, err error) {
+	br.Steps = append(br.Steps, &BookStep{
+		Name:        name,
+		At:          time.Now(),
+		Reservation: ri,
+		Err:         err,
+	})
+
+	if ri != nil {
+		br.Reservation = ri
+	}
+}
+
+// Book runs the full booking workflow: it creates an info reservation,
+// turns it into an option, waits for the option to be approved when needed
+// and turns it into a booking.
+//
+// If a step fails after the option was created, the option is released so
+// that no dangling options are left behind. The returned result lists every
+// step that was run, including the rollback, and is never nil.
+func (rsrv *ReservationService) Book(ctx context.Context, ir *InfoRequest, opts *BookOptions) (br *BookResult, err error) {
+	if opts == nil {
+		opts = &BookOptions{}
+	}
+
+	br = &BookResult{}
+
+	info, err := rsrv.CreateInfo(ir)
+	br.record(BookStepInfo, info, err)
+	if err != nil {
+		return br, fmt.Errorf("%s: %w", BookStepInfo, err)
+	}
+
+	option, err := rsrv.OptionFrom(info, opts.WaitingOption)
+	br.record(BookStepOption, option, err)
+	if err != nil {
+		return br, fmt.Errorf("%s: %w", BookStepOption, err)
+	}
+
+	if option.State() == ReservationStatusWaitingOption || (opts.NeedsOptionApproval && !option.Approved) {
+		option, err = rsrv.waitForOption(ctx, option, opts)
+		br.record(BookStepApproval, option, err)
+		if err != nil {
+			return br, rsrv.rollback(br, BookStepApproval, err)
+		}
+	}
+
+	if opts.OptionOnly {
+		return
+	}
+
+	booking, err := rsrv.BookingFrom(option)
+	br.record(BookStepBooking, booking, err)
+	if err != nil {
+		return br, rsrv.rollback(br, BookStepBooking, err)
+	}
+
+	return
+}
+
+// rollback releases the option of a failed workflow and returns the error
+// that made the workflow fail.
+func (rsrv *ReservationService) rollback(br *BookResult, step string, cause error) error {
+	r, err := rsrv.Release(br.Reservation)
+	br.record(BookStepRollback, r, err)
+	if err != nil {
+		return fmt.Errorf("%s: %w (rollback failed: %v)", step, cause, err)
+	}
+
+	br.RolledBack = true
+
+	return fmt.Errorf("%s: %w", step, cause)
+}
+
+// waitForOption watches the reservation until it is an approved option, the
+// context is done or the poll timeout is reached.
+func (rsrv *ReservationService) waitForOption(ctx context.Context, ri *ReservationInfo, opts *BookOptions) (*ReservationInfo, error) {
+	interval, timeout := opts.PollInterval, opts.PollTimeout
+	if interval <= 0 {
+		interval = DefaultBookPollInterval
+	}
+	if timeout <= 0 {
+		timeout = DefaultBookPollTimeout
+	}
+
+	var serr error
+	until := func(r *ReservationInfo) bool {
+		ri = r
+		switch r.State() {
+		case ReservationStatusOption:
+			return !opts.NeedsOptionApproval || r.Approved
+		case ReservationStatusWaitingOption:
+			return false
+		}
+
+		serr = &ReservationStatusError{ID: r.ID, Status: r.State(), Operation: "wait for option on"}
+
+		return true
+	}
+
+	err := rsrv.WatchFunc(ctx, []int64{ri.ID}, &WatchOptions{
+		InitialInterval: interval,
+		MaxInterval:     interval,
+		Deadline:        time.Now().Add(timeout),
+		Until:           until,
+	}, func(ReservationEvent) {})
+	if err != nil {
+		return ri, err
+	}
+
+	return ri, serr
+}
@@ -0,0 +1,128 @@
+package ns
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func bookSteps(br *BookResult) string {
+	var names []string
+	for _, s := range br.Steps {
+		names = append(names, s.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+func TestReservationService_Book(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.needsApproval = true
+
+	polls := 0
+	f.onPoll = func(ri *ReservationInfo) {
+		if polls++; polls == 2 {
+			ri.Approved = true
+		}
+	}
+
+	br, err := c.Reservation.Book(context.Background(), &InfoRequest{YachtID: 5}, &BookOptions{
+		NeedsOptionApproval: true,
+		PollInterval:        time.Millisecond,
+		PollTimeout:         time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := bookSteps(br); got != "info,option,approval,booking" {
+		t.Errorf("unexpected steps %s", got)
+	}
+	if br.RolledBack || br.Reservation.State() != ReservationStatusBooking {
+		t.Errorf("expected a booking, got %s", br.Reservation.State())
+	}
+}
+
+func TestReservationService_BookOptionRejected(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.reject = func(endpoint string, _ int64) int {
+		if endpoint == "createOption" {
+			return 110
+		}
+		return 0
+	}
+
+	br, err := c.Reservation.Book(context.Background(), &InfoRequest{YachtID: 5}, nil)
+	if err == nil || !strings.HasPrefix(err.Error(), BookStepOption) {
+		t.Fatalf("expected the option step to fail, got %v", err)
+	}
+
+	if got := bookSteps(br); got != "info,option" {
+		t.Errorf("unexpected steps %s", got)
+	}
+
+	// The rejection does not replace the info reservation.
+	if br.Reservation == nil || br.Reservation.ID == 0 || br.Reservation.State() != ReservationStatusInfo {
+		t.Errorf("expected the info reservation to be kept, got %+v", br.Reservation)
+	}
+
+	if n := f.count("cancelOption") + f.count("createBooking"); n != 0 {
+		t.Errorf("expected no further calls, got %d", n)
+	}
+}
+
+func TestReservationService_BookApprovalTimeout(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.needsApproval = true
+
+	br, err := c.Reservation.Book(context.Background(), &InfoRequest{YachtID: 5}, &BookOptions{
+		NeedsOptionApproval: true,
+		PollInterval:        time.Millisecond,
+		PollTimeout:         20 * time.Millisecond,
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the approval to time out, got %v", err)
+	}
+
+	if got := bookSteps(br); got != "info,option,approval,rollback" {
+		t.Errorf("unexpected steps %s", got)
+	}
+	if !br.RolledBack {
+		t.Error("expected the option to be rolled back")
+	}
+
+	if ri := f.get(br.Reservation.ID); ri.State() != ReservationStatusCancelled {
+		t.Errorf("expected the option to be cancelled, got %s", ri.State())
+	}
+	if n := f.count("createBooking"); n != 0 {
+		t.Errorf("expected no booking to be created, got %d", n)
+	}
+}
+
+func TestReservationService_BookBookingFailed(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.reject = func(endpoint string, _ int64) int {
+		if endpoint == "createBooking" {
+			return 130
+		}
+		return 0
+	}
+
+	br, err := c.Reservation.Book(context.Background(), &InfoRequest{YachtID: 5}, nil)
+	if err == nil || !strings.HasPrefix(err.Error(), BookStepBooking) {
+		t.Fatalf("expected the booking step to fail, got %v", err)
+	}
+
+	if got := bookSteps(br); got != "info,option,booking,rollback" {
+		t.Errorf("unexpected steps %s", got)
+	}
+	if !br.RolledBack || br.Reservation.State() != ReservationStatusCancelled {
+		t.Errorf("expected the option to be released, got %s", br.Reservation.State())
+	}
+
+	if n := f.count("cancelOption"); n != 1 {
+		t.Errorf("expected 1 cancellation, got %d", n)
+	}
+}
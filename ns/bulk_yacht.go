@@ -0,0 +1,110 @@
+package ns
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultWorkers is the number of concurrent requests used by bulk
+// operations when no worker count is given.
+const DefaultWorkers = 4
+
+// YachtResult holds the outcome of fetching a single yacht in bulk.
+type YachtResult struct {
+	Yacht *Yacht
+	Err   error
+}
+
+// FindMany retrieves the yachts with the given IDs using a pool of concurrent
+// workers. Requests go through the client so any rate limit set with
+// SetRateLimit is respected.
+//
+// Results are keyed by yacht ID, a failure to fetch one yacht is reported in
+// its own result and does not stop the others. If the context is done before
+// every yacht is dispatched, the remaining IDs are reported with the context
+// error and the same error is returned.
+func (sys *YachtsService) FindMany(ctx context.Context, ids []int64, workers int) (r map[int64]YachtResult, err error) {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	r = make(map[int64]YachtResult, len(ids))
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		jobs = make(chan int64)
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				yr := sys.findOne(ctx, id)
+				mu.Lock()
+				r[id] = yr
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	var pending []int64
+
+dispatch:
+	for i, id := range ids {
+		if seen[id] {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			pending = ids[i:]
+			break
+		}
+
+		select {
+		case jobs <- id:
+			seen[id] = true
+		case <-ctx.Done():
+			pending = ids[i:]
+			break dispatch
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	err = ctx.Err()
+	for _, id := range pending {
+		if _, ok := r[id]; !ok {
+			r[id] = YachtResult{Err: err}
+		}
+	}
+
+	return
+}
+
+func (sys *YachtsService) findOne(ctx context.Context, id int64) YachtResult {
+	ylr, err := sys.find(ctx, id)
+	if err != nil {
+		return YachtResult{Err: err}
+	}
+
+	if ylr.Status != "" && ylr.Status != "OK" {
+		return YachtResult{Err: fmt.Errorf("invalid response from provider: %s (Code: %d)", ylr.Status, ylr.ErrorCode)}
+	}
+
+	for i := range ylr.Yachts {
+		if ylr.Yachts[i].ID == id {
+			return YachtResult{Yacht: &ylr.Yachts[i]}
+		}
+	}
+
+	return YachtResult{Err: fmt.Errorf("yacht %d not found in provider response", id)}
+}
@@ -0,0 +1,65 @@
+package ns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestYachtsService_FindMany(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		if id == "3" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"OK","yachts":[{"id":%s,"name":"yacht %s"}]}`, id, id)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c, _ := NewClient(nil)
+	c.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	r, err := c.Yacht.FindMany(context.Background(), []int64{1, 2, 3, 2}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(r) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(r))
+	}
+
+	for _, id := range []int64{1, 2} {
+		if r[id].Err != nil || r[id].Yacht == nil || r[id].Yacht.ID != id {
+			t.Errorf("unexpected result for yacht %d: %+v", id, r[id])
+		}
+	}
+
+	if r[3].Err == nil {
+		t.Error("expected an error for yacht 3")
+	}
+}
+
+func TestYachtsService_FindManyCancelled(t *testing.T) {
+	c, _ := NewClient(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r, err := c.Yacht.FindMany(ctx, []int64{1, 2, 3}, 1)
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	for _, id := range []int64{1, 2, 3} {
+		if r[id].Err == nil {
+			t.Errorf("expected an error for yacht %d", id)
+		}
+	}
+}
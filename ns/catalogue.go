@@ -0,0 +1,445 @@
+package ns
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// CatalogueService operates over catalogue lookup requests.
+type CatalogueService service
+
+// Equipment returns all equipment entries.
+func (cs *CatalogueService) Equipment() (elr *EquipmentListResponse, err error) {
+	c := &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/equipment", CatalogueURL)
+
+	req, err := cs.client.NewAPIRequest(http.MethodPost, target, c)
+	if err != nil {
+		return
+	}
+
+	res, err := cs.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &elr); err != nil {
+		return
+	}
+
+	return
+}
+
+// EquipmentCategories returns all equipment categories.
+func (cs *CatalogueService) EquipmentCategories() (eclr *EquipmentCategoryListResponse, err error) {
+	c := &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/equipmentCategories", CatalogueURL)
+
+	req, err := cs.client.NewAPIRequest(http.MethodPost, target, c)
+	if err != nil {
+		return
+	}
+
+	res, err := cs.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &eclr); err != nil {
+		return
+	}
+
+	return
+}
+
+// Services returns all services.
+func (cs *CatalogueService) Services() (slr *ServiceListResponse, err error) {
+	c := &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/services", CatalogueURL)
+
+	req, err := cs.client.NewAPIRequest(http.MethodPost, target, c)
+	if err != nil {
+		return
+	}
+
+	res, err := cs.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &slr); err != nil {
+		return
+	}
+
+	return
+}
+
+// PriceMeasures returns all price measures.
+func (cs *CatalogueService) PriceMeasures() (pmlr *PriceMeasureListResponse, err error) {
+	c := &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/priceMeasures", CatalogueURL)
+
+	req, err := cs.client.NewAPIRequest(http.MethodPost, target, c)
+	if err != nil {
+		return
+	}
+
+	res, err := cs.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &pmlr); err != nil {
+		return
+	}
+
+	return
+}
+
+// Seasons returns all seasons.
+func (cs *CatalogueService) Seasons() (slr *SeasonListResponse, err error) {
+	c := &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/seasons", CatalogueURL)
+
+	req, err := cs.client.NewAPIRequest(http.MethodPost, target, c)
+	if err != nil {
+		return
+	}
+
+	res, err := cs.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &slr); err != nil {
+		return
+	}
+
+	return
+}
+
+// SteeringTypes returns all steering types.
+func (cs *CatalogueService) SteeringTypes() (stlr *SteeringTypeListResponse, err error) {
+	c := &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/steeringTypes", CatalogueURL)
+
+	req, err := cs.client.NewAPIRequest(http.MethodPost, target, c)
+	if err != nil {
+		return
+	}
+
+	res, err := cs.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &stlr); err != nil {
+		return
+	}
+
+	return
+}
+
+// SailTypes returns all sail and genoa types.
+func (cs *CatalogueService) SailTypes() (stlr *SailTypeListResponse, err error) {
+	c := &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/sailTypes", CatalogueURL)
+
+	req, err := cs.client.NewAPIRequest(http.MethodPost, target, c)
+	if err != nil {
+		return
+	}
+
+	res, err := cs.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &stlr); err != nil {
+		return
+	}
+
+	return
+}
+
+// Flags returns all yacht flags.
+func (cs *CatalogueService) Flags() (flr *FlagListResponse, err error) {
+	c := &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/flags", CatalogueURL)
+
+	req, err := cs.client.NewAPIRequest(http.MethodPost, target, c)
+	if err != nil {
+		return
+	}
+
+	res, err := cs.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &flr); err != nil {
+		return
+	}
+
+	return
+}
+
+// CatalogueIndex holds catalogue entries indexed by their IDs so that
+// the IDs referenced by yachts can be resolved without further requests.
+type CatalogueIndex struct {
+	Equipment           map[int64]Equipment
+	EquipmentCategories map[int64]EquipmentCategory
+	Services            map[int64]Service
+	PriceMeasures       map[int64]PriceMeasure
+	Seasons             map[int64]Season
+	SteeringTypes       map[int64]SteeringType
+	SailTypes           map[int64]SailType
+	Flags               map[int64]Flag
+}
+
+// Index fetches the equipment, equipment category, service, price measure,
+// season, steering type, sail type and flag catalogues and returns them
+// indexed by ID.
+func (cs *CatalogueService) Index() (ci *CatalogueIndex, err error) {
+	ci = &CatalogueIndex{
+		Equipment:           make(map[int64]Equipment),
+		EquipmentCategories: make(map[int64]EquipmentCategory),
+		Services:            make(map[int64]Service),
+		PriceMeasures:       make(map[int64]PriceMeasure),
+		Seasons:             make(map[int64]Season),
+		SteeringTypes:       make(map[int64]SteeringType),
+		SailTypes:           make(map[int64]SailType),
+		Flags:               make(map[int64]Flag),
+	}
+
+	elr, err := cs.Equipment()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range elr.Equipment {
+		ci.Equipment[e.ID] = e
+	}
+
+	eclr, err := cs.EquipmentCategories()
+	if err != nil {
+		return nil, err
+	}
+	for _, ec := range eclr.EquipmentCategories {
+		ci.EquipmentCategories[ec.ID] = ec
+	}
+
+	slr, err := cs.Services()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range slr.Services {
+		ci.Services[s.ID] = s
+	}
+
+	pmlr, err := cs.PriceMeasures()
+	if err != nil {
+		return nil, err
+	}
+	for _, pm := range pmlr.PriceMeasures {
+		ci.PriceMeasures[pm.ID] = pm
+	}
+
+	sslr, err := cs.Seasons()
+	if err != nil {
+		return nil, err
+	}
+	for _, se := range sslr.Seasons {
+		ci.Seasons[se.ID] = se
+	}
+
+	stlr, err := cs.SteeringTypes()
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range stlr.SteeringTypes {
+		ci.SteeringTypes[st.ID] = st
+	}
+
+	satlr, err := cs.SailTypes()
+	if err != nil {
+		return nil, err
+	}
+	for _, sat := range satlr.SailTypes {
+		ci.SailTypes[sat.ID] = sat
+	}
+
+	flr, err := cs.Flags()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range flr.Flags {
+		ci.Flags[f.ID] = f
+	}
+
+	return
+}
+
+// YachtDescription is a yacht annotated with human-readable
+// equipment, service, rigging and flag names.
+type YachtDescription struct {
+	Yacht               *Yacht
+	SteeringType        string
+	SailType            string
+	GenoaType           string
+	Flags               []string
+	StandardEquipment   []EquipmentDescription
+	AdditionalEquipment []EquipmentDescription
+	Services            []ServiceDescription
+}
+
+// EquipmentDescription is a yacht equipment entry with resolved names.
+type EquipmentDescription struct {
+	SeasonID     int64
+	EquipmentID  int64
+	Name         string
+	Category     string
+	PriceMeasure string
+	Quantity     int
+	Highlight    bool
+	Comment      string
+}
+
+// ServiceDescription is a yacht service entry with resolved names.
+type ServiceDescription struct {
+	SeasonID     int64
+	ServiceID    int64
+	Name         string
+	PriceMeasure string
+	Obligatory   bool
+	Description  string
+}
+
+// DescribeYacht resolves the catalogue IDs of a yacht
+// into names translated to the given language. Entries missing from
+// the index are kept with an empty name.
+func (ci *CatalogueIndex) DescribeYacht(y *Yacht, lang string) *YachtDescription {
+	yd := &YachtDescription{
+		Yacht:        y,
+		SteeringType: ci.SteeringTypes[y.SteeringTypeID].Name.Localized(lang),
+		SailType:     ci.SailTypes[y.SailTypeID].Name.Localized(lang),
+		GenoaType:    ci.SailTypes[y.GenoaTypeID].Name.Localized(lang),
+	}
+
+	for _, f := range y.FlagsID {
+		yd.Flags = append(yd.Flags, ci.Flags[f].Name.Localized(lang))
+	}
+
+	for _, ye := range y.StandardYachtEquipment {
+		ed := ci.describeEquipment(ye.EquipmentID, lang)
+		ed.Quantity = ye.Quantity
+		ed.Highlight = ye.Highlight
+		ed.Comment = ye.Comment.Localized(lang)
+		yd.StandardEquipment = append(yd.StandardEquipment, ed)
+	}
+
+	for _, ys := range y.SeasonSpecificData {
+		for _, ae := range ys.AdditionalYachtEquipment {
+			ed := ci.describeEquipment(ae.EquipmentID, lang)
+			ed.SeasonID = ys.SeasonID
+			ed.Quantity = ae.Quantity
+			ed.Comment = ae.Comment.Localized(lang)
+			ed.PriceMeasure = ci.PriceMeasures[ae.PriceMeasureID].Name.Localized(lang)
+			yd.AdditionalEquipment = append(yd.AdditionalEquipment, ed)
+		}
+
+		for _, s := range ys.Services {
+			yd.Services = append(yd.Services, ServiceDescription{
+				SeasonID:     ys.SeasonID,
+				ServiceID:    s.ServiceID,
+				Name:         ci.Services[s.ServiceID].Name.Localized(lang),
+				PriceMeasure: ci.PriceMeasures[s.PriceMeasureID].Name.Localized(lang),
+				Obligatory:   s.Obligatory,
+				Description:  s.Description.Localized(lang),
+			})
+		}
+	}
+
+	return yd
+}
+
+func (ci *CatalogueIndex) describeEquipment(id int64, lang string) EquipmentDescription {
+	e := ci.Equipment[id]
+
+	return EquipmentDescription{
+		EquipmentID: id,
+		Name:        e.Name.Localized(lang),
+		Category:    ci.EquipmentCategories[e.CategoryID].Name.Localized(lang),
+	}
+}
+
+// Contains reports whether the given date falls within the season,
+// both ends inclusive. Only the calendar date of t is considered.
+func (s *Season) Contains(t time.Time) bool {
+	if s.DateFrom == nil || s.DateTo == nil {
+		return false
+	}
+
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	return !d.Before(s.DateFrom.Time) && !d.After(s.DateTo.Time)
+}
+
+// SeasonFor returns the season specific data of a yacht that applies to the
+// given charter date, along with the matching season. When several seasons
+// cover the date the first one not marked as default wins. It returns nil
+// when no season matches.
+func (ci *CatalogueIndex) SeasonFor(y *Yacht, date time.Time) (*YachtSeason, *Season) {
+	var (
+		ys *YachtSeason
+		se *Season
+	)
+
+	for i := range y.SeasonSpecificData {
+		s, ok := ci.Seasons[y.SeasonSpecificData[i].SeasonID]
+		if !ok || !s.Contains(date) {
+			continue
+		}
+
+		if se == nil || (se.DefaultSeason && !s.DefaultSeason) {
+			s := s
+			ys, se = &y.SeasonSpecificData[i], &s
+		}
+	}
+
+	return ys, se
+}
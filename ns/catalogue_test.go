@@ -0,0 +1,229 @@
+package ns
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testCatalogueIndex() *CatalogueIndex {
+	return &CatalogueIndex{
+		Equipment: map[int64]Equipment{
+			7: {ID: 7, CategoryID: 1, Name: InternationalText{TextEN: "Dinghy", TextDE: "Beiboot"}},
+		},
+		EquipmentCategories: map[int64]EquipmentCategory{
+			1: {ID: 1, Name: InternationalText{TextEN: "Deck"}},
+		},
+		Services: map[int64]Service{
+			3: {ID: 3, Name: InternationalText{TextEN: "Transit log", TextDE: "Transitlog"}},
+		},
+		PriceMeasures: map[int64]PriceMeasure{
+			2: {ID: 2, Name: InternationalText{TextEN: "per week"}},
+		},
+		SteeringTypes: map[int64]SteeringType{
+			4: {ID: 4, Name: InternationalText{TextEN: "Wheel", TextDE: "Rad"}},
+		},
+		SailTypes: map[int64]SailType{
+			5: {ID: 5, Name: InternationalText{TextEN: "Furling mainsail"}},
+			6: {ID: 6, Name: InternationalText{TextEN: "Furling genoa"}},
+		},
+		Flags: map[int64]Flag{
+			8: {ID: 8, Name: InternationalText{TextEN: "Croatia"}},
+		},
+	}
+}
+
+func TestCatalogueIndex_DescribeYacht(t *testing.T) {
+	ci := testCatalogueIndex()
+	y := &Yacht{
+		SteeringTypeID: 4,
+		SailTypeID:     5,
+		GenoaTypeID:    6,
+		FlagsID:        []int64{8, 99},
+		StandardYachtEquipment: []YachtEquipment{
+			{EquipmentID: 7, Quantity: 1, Highlight: true},
+		},
+		SeasonSpecificData: []YachtSeason{{
+			SeasonID: 10,
+			AdditionalYachtEquipment: []AdditionalYachtEquipment{
+				{EquipmentID: 7, Quantity: 2, PriceMeasureID: 2},
+			},
+			Services: []YachtService{
+				{ServiceID: 3, PriceMeasureID: 2, Obligatory: true},
+			},
+		}},
+	}
+
+	yd := ci.DescribeYacht(y, "de")
+
+	if yd.SteeringType != "Rad" || yd.SailType != "Furling mainsail" || yd.GenoaType != "Furling genoa" {
+		t.Errorf("unexpected rigging: %q, %q, %q", yd.SteeringType, yd.SailType, yd.GenoaType)
+	}
+
+	// Unknown flags are kept with an empty name.
+	if len(yd.Flags) != 2 || yd.Flags[0] != "Croatia" || yd.Flags[1] != "" {
+		t.Errorf("unexpected flags: %q", yd.Flags)
+	}
+
+	if len(yd.StandardEquipment) != 1 {
+		t.Fatalf("expected 1 standard equipment entry, got %d", len(yd.StandardEquipment))
+	}
+	if se := yd.StandardEquipment[0]; se.Name != "Beiboot" || se.Category != "Deck" || !se.Highlight {
+		t.Errorf("unexpected standard equipment: %+v", se)
+	}
+
+	if len(yd.AdditionalEquipment) != 1 {
+		t.Fatalf("expected 1 additional equipment entry, got %d", len(yd.AdditionalEquipment))
+	}
+	if ae := yd.AdditionalEquipment[0]; ae.SeasonID != 10 || ae.Quantity != 2 || ae.PriceMeasure != "per week" {
+		t.Errorf("unexpected additional equipment: %+v", ae)
+	}
+
+	if len(yd.Services) != 1 {
+		t.Fatalf("expected 1 service, got %d", len(yd.Services))
+	}
+	if s := yd.Services[0]; s.Name != "Transitlog" || s.PriceMeasure != "per week" || !s.Obligatory {
+		t.Errorf("unexpected service: %+v", s)
+	}
+}
+
+func TestCatalogueIndex_SeasonFor(t *testing.T) {
+	date := func(d int, m time.Month) *NausysDate {
+		return &NausysDate{time.Date(2021, m, d, 0, 0, 0, 0, time.UTC)}
+	}
+
+	ci := &CatalogueIndex{Seasons: map[int64]Season{
+		1: {ID: 1, DateFrom: date(1, 1), DateTo: date(31, 12), DefaultSeason: true},
+		2: {ID: 2, DateFrom: date(1, 6), DateTo: date(30, 9)},
+		3: {ID: 3, DateFrom: date(1, 10), DateTo: date(31, 10)},
+		4: {ID: 4, DateFrom: date(1, 1), DateTo: date(31, 12), DefaultSeason: true},
+	}}
+
+	tests := []struct {
+		name    string
+		seasons []int64
+		date    time.Time
+		want    int64
+	}{
+		{"specific season wins over default", []int64{1, 2, 3}, date(15, 7).Time, 2},
+		{"specific season wins listed after", []int64{2, 1}, date(15, 7).Time, 2},
+		{"default season alone", []int64{1, 2, 3}, date(15, 3).Time, 1},
+		{"first of two defaults", []int64{4, 1}, date(15, 3).Time, 4},
+		{"boundary is inclusive", []int64{1, 3}, date(31, 10).Time, 3},
+		{"no match", []int64{2, 3}, date(15, 3).Time, 0},
+		{"unknown season", []int64{99}, date(15, 7).Time, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			y := &Yacht{}
+			for _, id := range tt.seasons {
+				y.SeasonSpecificData = append(y.SeasonSpecificData, YachtSeason{SeasonID: id})
+			}
+
+			ys, s := ci.SeasonFor(y, tt.date)
+			if tt.want == 0 {
+				if ys != nil || s != nil {
+					t.Errorf("expected no season, got %+v", s)
+				}
+				return
+			}
+
+			if ys == nil || s == nil || ys.SeasonID != tt.want || s.ID != tt.want {
+				t.Errorf("expected season %d, got %+v", tt.want, s)
+			}
+		})
+	}
+}
+
+func newCatalogueServer(t *testing.T, failing string) (*Client, func() []string) {
+	t.Helper()
+
+	bodies := map[string]string{
+		"equipment":           `"equipment":[{"id":7,"categoryId":1,"name":{"textEN":"Dinghy"}}]`,
+		"equipmentCategories": `"equipmentCategories":[{"id":1,"name":{"textEN":"Deck"}}]`,
+		"services":            `"services":[{"id":3,"name":{"textEN":"Transit log"}}]`,
+		"priceMeasures":       `"priceMeasures":[{"id":2,"name":{"textEN":"per week"}}]`,
+		"seasons":             `"seasons":[{"id":10,"dateFrom":"01.01.2021","dateTo":"31.12.2021","defaultSeason":true}]`,
+		"steeringTypes":       `"steeringTypes":[{"id":4,"name":{"textEN":"Wheel"}}]`,
+		"sailTypes":           `"sailTypes":[{"id":5,"name":{"textEN":"Furling mainsail"}}]`,
+		"flags":               `"flags":[{"id":8,"name":{"textEN":"Croatia"}}]`,
+	}
+
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		name := strings.TrimPrefix(r.URL.Path, "/catalogue/v6/")
+		body, ok := bodies[name]
+		if !ok || name == failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"OK",%s}`, body)
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	c, _ := NewClient(nil)
+	c.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	return c, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), paths...)
+	}
+}
+
+func TestCatalogueService_Index(t *testing.T) {
+	c, paths := newCatalogueServer(t, "")
+
+	ci, err := c.Catalogue.Index()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "[/catalogue/v6/equipment /catalogue/v6/equipmentCategories /catalogue/v6/services " +
+		"/catalogue/v6/priceMeasures /catalogue/v6/seasons /catalogue/v6/steeringTypes " +
+		"/catalogue/v6/sailTypes /catalogue/v6/flags]"
+	if got := fmt.Sprint(paths()); got != want {
+		t.Errorf("got paths %s, want %s", got, want)
+	}
+
+	if ci.Equipment[7].CategoryID != 1 || ci.EquipmentCategories[1].Name.TextEN != "Deck" ||
+		ci.Services[3].Name.TextEN != "Transit log" || ci.PriceMeasures[2].Name.TextEN != "per week" ||
+		ci.SteeringTypes[4].Name.TextEN != "Wheel" || ci.SailTypes[5].Name.TextEN != "Furling mainsail" ||
+		ci.Flags[8].Name.TextEN != "Croatia" {
+		t.Errorf("unexpected index %+v", ci)
+	}
+
+	if s := ci.Seasons[10]; !s.DefaultSeason || s.DateTo == nil || s.DateTo.Month() != time.December {
+		t.Errorf("unexpected season %+v", s)
+	}
+}
+
+func TestCatalogueService_IndexError(t *testing.T) {
+	c, paths := newCatalogueServer(t, "seasons")
+
+	ci, err := c.Catalogue.Index()
+	if err == nil || ci != nil {
+		t.Fatalf("expected the failing catalogue to fail the index, got %+v", ci)
+	}
+
+	// The catalogues after the failing one are not requested.
+	if got := paths(); len(got) != 5 || got[4] != "/catalogue/v6/seasons" {
+		t.Errorf("unexpected paths %v", got)
+	}
+}
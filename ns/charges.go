@@ -0,0 +1,164 @@
+package ns
+
+import (
+	"fmt"
+	"time"
+)
+
+// Extra calculation types.
+const (
+	CalculationTypePerBooking       = "PER_BOOKING"
+	CalculationTypePerDay           = "PER_DAY"
+	CalculationTypePerWeek          = "PER_WEEK"
+	CalculationTypePerPerson        = "PER_PERSON"
+	CalculationTypePerPersonPerDay  = "PER_PERSON_PER_DAY"
+	CalculationTypePerPersonPerWeek = "PER_PERSON_PER_WEEK"
+)
+
+// Percentage calculation types, telling which price a percentage extra is
+// computed on.
+const (
+	PercentageCalculationTypePriceList = "PRICE_LIST_PRICE"
+	PercentageCalculationTypeClient    = "CLIENT_PRICE"
+)
+
+// Extra charge kinds.
+const (
+	ExtraKindService   = "service"
+	ExtraKindEquipment = "equipment"
+)
+
+// ChargeContext describes the charter extras are evaluated for.
+type ChargeContext struct {
+	PeriodFrom time.Time
+	PeriodTo   time.Time
+	// Pax is the number of people on board, zero when unknown.
+	Pax int
+	// BaseID is the check-in base, zero when unknown.
+	BaseID int64
+	// PriceListPrice and ClientPrice are the charter prices before and after
+	// discounts, used by percentage extras.
+	PriceListPrice Money
+	ClientPrice    Money
+}
+
+func (cc *ChargeContext) nights() int64 {
+	return int64(dateOf(cc.PeriodTo).Sub(dateOf(cc.PeriodFrom)).Hours() / 24)
+}
+
+// ExtraCharge is the evaluation of a service or additional equipment entry
+// for a charter. Reason explains why an extra is not applicable.
+type ExtraCharge struct {
+	Kind        string
+	ID          int64
+	Description string
+	Applicable  bool
+	Obligatory  bool
+	Reason      string
+	Charge      Money
+}
+
+// EvaluateService computes the charge of a yacht service for the charter
+// and whether it applies to it.
+func EvaluateService(s *YachtService, cc *ChargeContext) *ExtraCharge {
+	ec := &ExtraCharge{
+		Kind:        ExtraKindService,
+		ID:          s.ID,
+		Description: s.Description.Localized("EN"),
+		Obligatory:  s.Obligatory,
+	}
+
+	switch {
+	case !inDateRange(dateOf(cc.PeriodFrom), s.ValidPeriodFrom, s.ValidPeriodTo):
+		ec.Reason = "not valid for the charter dates"
+	case cc.Pax > 0 && s.ValidMinPax > 0 && cc.Pax < s.ValidMinPax:
+		ec.Reason = fmt.Sprintf("requires at least %d people", s.ValidMinPax)
+	case cc.Pax > 0 && s.ValidMaxPax > 0 && cc.Pax > s.ValidMaxPax:
+		ec.Reason = fmt.Sprintf("allows at most %d people", s.ValidMaxPax)
+	case cc.BaseID != 0 && len(s.ValidForBases) > 0 && !containsID(s.ValidForBases, cc.BaseID):
+		ec.Reason = "not valid for the check-in base"
+	default:
+		ec.Applicable = true
+	}
+
+	ec.Charge = extraCharge(s.Price, s.Amount, s.AmountIsPercentage, s.PercentageCalculationType, s.CalculationType, s.MinimumPrice, cc)
+
+	return ec
+}
+
+// EvaluateEquipment computes the charge of additional equipment for the
+// charter and whether it applies to it.
+func EvaluateEquipment(e *AdditionalYachtEquipment, cc *ChargeContext) *ExtraCharge {
+	ec := &ExtraCharge{
+		Kind:        ExtraKindEquipment,
+		ID:          e.ID,
+		Description: e.Comment.Localized("EN"),
+	}
+
+	if cc.BaseID != 0 && len(e.ValidForBases) > 0 && !containsID(e.ValidForBases, cc.BaseID) {
+		ec.Reason = "not valid for the check-in base"
+	} else {
+		ec.Applicable = true
+	}
+
+	ec.Charge = extraCharge(e.Price, e.Amount, e.AmountIsPercentage, e.PercentageCalculationType, e.CalculationType, e.MinimumPrice, cc)
+
+	return ec
+}
+
+// EvaluateExtras evaluates every service and additional equipment entry of
+// a season for the charter.
+func EvaluateExtras(ys *YachtSeason, cc *ChargeContext) []*ExtraCharge {
+	ecs := make([]*ExtraCharge, 0, len(ys.Services)+len(ys.AdditionalYachtEquipment))
+
+	for i := range ys.Services {
+		ecs = append(ecs, EvaluateService(&ys.Services[i], cc))
+	}
+
+	for i := range ys.AdditionalYachtEquipment {
+		ecs = append(ecs, EvaluateEquipment(&ys.AdditionalYachtEquipment[i], cc))
+	}
+
+	return ecs
+}
+
+// extraCharge computes the charge of an extra. Percentage extras are a
+// percentage of the charter price, others are priced per unit of their
+// calculation type. The minimum price applies to both.
+func extraCharge(price, amount Money, isPercentage bool, percentageType, calculationType string, minimum Money, cc *ChargeContext) Money {
+	unit := price
+	if isPercentage {
+		on := cc.PriceListPrice
+		if percentageType == PercentageCalculationTypeClient {
+			on = cc.ClientPrice
+		}
+		unit = on.Percent(amount)
+	}
+
+	nights, pax := cc.nights(), int64(cc.Pax)
+	if pax <= 0 {
+		pax = 1
+	}
+
+	charge := unit
+	switch calculationType {
+	case CalculationTypePerDay:
+		charge = unit.Mul(nights)
+	case CalculationTypePerWeek:
+		charge = unit.MulFrac(nights, 7)
+	case CalculationTypePerPerson:
+		charge = unit.Mul(pax)
+	case CalculationTypePerPersonPerDay:
+		charge = unit.Mul(pax * nights)
+	case CalculationTypePerPersonPerWeek:
+		charge = unit.Mul(pax).MulFrac(nights, 7)
+	}
+
+	if !minimum.IsZero() {
+		if c, err := charge.Cmp(minimum); err == nil && c < 0 {
+			charge = minimum
+		}
+	}
+
+	return charge
+}
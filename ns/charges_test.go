@@ -0,0 +1,74 @@
+package ns
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluateService(t *testing.T) {
+	cc := &ChargeContext{
+		PeriodFrom:     time.Date(2021, 7, 3, 0, 0, 0, 0, time.UTC),
+		PeriodTo:       time.Date(2021, 7, 10, 0, 0, 0, 0, time.UTC),
+		Pax:            6,
+		BaseID:         3,
+		PriceListPrice: NewMoney(300000, "EUR"),
+		ClientPrice:    NewMoney(270000, "EUR"),
+	}
+
+	tests := []struct {
+		name       string
+		service    YachtService
+		applicable bool
+		charge     string
+	}{
+		{
+			"per person per day",
+			YachtService{CalculationType: CalculationTypePerPersonPerDay, Price: NewMoney(500, "EUR")},
+			true,
+			"210.00 EUR",
+		},
+		{
+			"percentage of client price",
+			YachtService{AmountIsPercentage: true, Amount: NewMoney(1000, ""), PercentageCalculationType: PercentageCalculationTypeClient},
+			true,
+			"270.00 EUR",
+		},
+		{
+			"minimum price",
+			YachtService{CalculationType: CalculationTypePerBooking, Price: NewMoney(1000, "EUR"), MinimumPrice: NewMoney(5000, "EUR")},
+			true,
+			"50.00 EUR",
+		},
+		{
+			"too many people",
+			YachtService{ValidMaxPax: 4, Price: NewMoney(1000, "EUR")},
+			false,
+			"10.00 EUR",
+		},
+		{
+			"outside validity",
+			YachtService{ValidPeriodFrom: "01.08.2021", ValidPeriodTo: "31.08.2021"},
+			false,
+			"0.00",
+		},
+		{
+			"other base",
+			YachtService{ValidForBases: []int64{1, 2}},
+			false,
+			"0.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ec := EvaluateService(&tt.service, cc)
+			if ec.Applicable != tt.applicable {
+				t.Errorf("applicable = %v, want %v (%s)", ec.Applicable, tt.applicable, ec.Reason)
+			}
+
+			if got := ec.Charge.String(); got != tt.charge {
+				t.Errorf("charge = %s, want %s", got, tt.charge)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package ns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Check-in rules a charter period can break.
+const (
+	CheckInRuleInvalidPeriod   = "invalid_period"
+	CheckInRuleNoCheckInPeriod = "no_check_in_period"
+	CheckInRuleCheckInDay      = "check_in_day"
+	CheckInRuleCheckOutDay     = "check_out_day"
+	CheckInRuleMinimumDuration = "minimum_duration"
+	CheckInRuleOneWay          = "one_way"
+)
+
+// CheckInViolation is a check-in rule broken by a charter period.
+type CheckInViolation struct {
+	Rule    string
+	Message string
+}
+
+// CheckInError is returned when a charter period breaks the check-in rules
+// of a yacht.
+type CheckInError struct {
+	YachtID    int64
+	Violations []*CheckInViolation
+}
+
+// Error function complies with the error interface.
+func (e *CheckInError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.Message)
+	}
+
+	return fmt.Sprintf("yacht %d: %s", e.YachtID, strings.Join(msgs, "; "))
+}
+
+// CharterPeriod is a requested charter, checked against the check-in rules
+// of a yacht.
+type CharterPeriod struct {
+	PeriodFrom time.Time
+	PeriodTo   time.Time
+	// BaseFromID and BaseToID are the check-in and check-out bases. A
+	// different, non zero, check-out base makes the charter one way.
+	BaseFromID int64
+	BaseToID   int64
+}
+
+// Contains reports whether the given date falls within the check-in period,
+// both ends inclusive. Open ends match any date.
+func (cp *CheckInPeriod) Contains(t time.Time) bool {
+	d := dateOf(t)
+
+	return (cp.DateFrom == nil || !d.Before(cp.DateFrom.Time)) && (cp.DateTo == nil || !d.After(cp.DateTo.Time))
+}
+
+// CheckInOn reports whether check-in is allowed on the given weekday.
+func (cp *CheckInPeriod) CheckInOn(wd time.Weekday) bool {
+	return [...]bool{
+		cp.CheckInSunday, cp.CheckInMonday, cp.CheckInTuesday, cp.CheckInWednesday,
+		cp.CheckInThursday, cp.CheckInFriday, cp.CheckInSaturday,
+	}[wd]
+}
+
+// CheckOutOn reports whether check-out is allowed on the given weekday.
+func (cp *CheckInPeriod) CheckOutOn(wd time.Weekday) bool {
+	return [...]bool{
+		cp.CheckOutSunday, cp.CheckOutMonday, cp.CheckOutTuesday, cp.CheckOutWednesday,
+		cp.CheckOutThursday, cp.CheckOutFriday, cp.CheckOutSaturday,
+	}[wd]
+}
+
+// CheckInPeriodOn returns the check-in period of the yacht covering the
+// given date, nil when there is none.
+func (y *Yacht) CheckInPeriodOn(t time.Time) *CheckInPeriod {
+	for i := range y.CheckInPeriods {
+		if y.CheckInPeriods[i].Contains(t) {
+			return &y.CheckInPeriods[i]
+		}
+	}
+
+	return nil
+}
+
+// CheckPeriod returns the check-in rules of the yacht broken by the charter.
+// The rules are the ones of the check-in period covering the check-in date.
+// Yachts without check-in periods accept any period. One way charters also
+// need a one way period towards the check-out base covering the check-in date.
+func (y *Yacht) CheckPeriod(cp *CharterPeriod) (vs []*CheckInViolation) {
+	from, to := dateOf(cp.PeriodFrom), dateOf(cp.PeriodTo)
+	if !to.After(from) {
+		return []*CheckInViolation{{
+			Rule:    CheckInRuleInvalidPeriod,
+			Message: fmt.Sprintf("check-out %s is not after check-in %s", to.Format("02.01.2006"), from.Format("02.01.2006")),
+		}}
+	}
+
+	if len(y.CheckInPeriods) > 0 {
+		vs = append(vs, checkInPeriodRules(y.CheckInPeriodOn(from), from, to)...)
+	}
+
+	if cp.BaseToID != 0 && cp.BaseToID != cp.BaseFromID && !y.oneWayAllowed(cp.BaseToID, from) {
+		vs = append(vs, &CheckInViolation{
+			Rule:    CheckInRuleOneWay,
+			Message: fmt.Sprintf("one way charters to base %d are not allowed on %s", cp.BaseToID, from.Format("02.01.2006")),
+		})
+	}
+
+	return
+}
+
+// ValidatePeriod checks the charter against the check-in rules of the yacht,
+// returning a *CheckInError listing every broken rule.
+func (y *Yacht) ValidatePeriod(cp *CharterPeriod) error {
+	if vs := y.CheckPeriod(cp); len(vs) > 0 {
+		return &CheckInError{YachtID: y.ID, Violations: vs}
+	}
+
+	return nil
+}
+
+// ValidateCheckIn checks the period of the request against the check-in
+// rules of the yacht, so that requests Nausys would reject are caught before
+// calling CreateInfo.
+func (ir *InfoRequest) ValidateCheckIn(y *Yacht) error {
+	if ir.PeriodFrom == nil || ir.PeriodTo == nil {
+		return &CheckInError{YachtID: y.ID, Violations: []*CheckInViolation{{
+			Rule:    CheckInRuleInvalidPeriod,
+			Message: "the charter period is missing",
+		}}}
+	}
+
+	return y.ValidatePeriod(&CharterPeriod{PeriodFrom: ir.PeriodFrom.Time, PeriodTo: ir.PeriodTo.Time})
+}
+
+// checkInPeriodRules returns the rules of the check-in period broken by the
+// charter.
+func checkInPeriodRules(p *CheckInPeriod, from, to time.Time) (vs []*CheckInViolation) {
+	if p == nil {
+		return []*CheckInViolation{{
+			Rule:    CheckInRuleNoCheckInPeriod,
+			Message: fmt.Sprintf("no check-in is possible on %s", from.Format("02.01.2006")),
+		}}
+	}
+
+	if !p.CheckInOn(from.Weekday()) {
+		vs = append(vs, &CheckInViolation{
+			Rule:    CheckInRuleCheckInDay,
+			Message: fmt.Sprintf("check-in is not allowed on %s, allowed days are %s", from.Weekday(), weekdayList(p.CheckInOn)),
+		})
+	}
+
+	if !p.CheckOutOn(to.Weekday()) {
+		vs = append(vs, &CheckInViolation{
+			Rule:    CheckInRuleCheckOutDay,
+			Message: fmt.Sprintf("check-out is not allowed on %s, allowed days are %s", to.Weekday(), weekdayList(p.CheckOutOn)),
+		})
+	}
+
+	if nights := int(to.Sub(from).Hours() / 24); nights < p.MinimalReservationDuration {
+		vs = append(vs, &CheckInViolation{
+			Rule:    CheckInRuleMinimumDuration,
+			Message: fmt.Sprintf("the charter lasts %d days, the minimum is %d days", nights, p.MinimalReservationDuration),
+		})
+	}
+
+	return
+}
+
+// oneWayAllowed reports whether a one way period towards the base covers
+// the check-in date.
+func (y *Yacht) oneWayAllowed(baseID int64, from time.Time) bool {
+	for _, owp := range y.OneWayPeriods {
+		if owp.BaseID != baseID {
+			continue
+		}
+
+		if (owp.PeriodFrom == nil || !from.Before(owp.PeriodFrom.Time)) && (owp.PeriodTo == nil || !from.After(owp.PeriodTo.Time)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// weekdayList lists the weekdays, starting on Monday, for which allowed
+// returns true.
+func weekdayList(allowed func(time.Weekday) bool) string {
+	var days []string
+	for i := 1; i <= 7; i++ {
+		if wd := time.Weekday(i % 7); allowed(wd) {
+			days = append(days, wd.String())
+		}
+	}
+
+	if len(days) == 0 {
+		return "none"
+	}
+
+	return strings.Join(days, ", ")
+}
@@ -0,0 +1,99 @@
+package ns
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+const testCheckInYacht = `{
+	"id": 1,
+	"checkInPeriods": [{
+		"dateFrom": "01.05.2021",
+		"dateTo": "30.09.2021",
+		"minimalReservationDuration": 7,
+		"checkInSaturday": true,
+		"checkOutSaturday": true
+	}],
+	"oneWayPeriods": [{"id": 1, "periodFrom": "01.06.2021", "periodTo": "31.08.2021", "baseId": 20}]
+}`
+
+func TestYachtCheckPeriod(t *testing.T) {
+	var y Yacht
+	if err := json.Unmarshal([]byte(testCheckInYacht), &y); err != nil {
+		t.Fatal(err)
+	}
+
+	day := func(d int, m time.Month) time.Time {
+		return time.Date(2021, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		cp    CharterPeriod
+		rules []string
+	}{
+		{"valid", CharterPeriod{PeriodFrom: day(5, 6), PeriodTo: day(12, 6)}, nil},
+		{"wrong days", CharterPeriod{PeriodFrom: day(7, 6), PeriodTo: day(11, 6)}, []string{CheckInRuleCheckInDay, CheckInRuleCheckOutDay, CheckInRuleMinimumDuration}},
+		{"out of season", CharterPeriod{PeriodFrom: day(2, 10), PeriodTo: day(9, 10)}, []string{CheckInRuleNoCheckInPeriod}},
+		{"one way", CharterPeriod{PeriodFrom: day(5, 6), PeriodTo: day(12, 6), BaseFromID: 10, BaseToID: 20}, nil},
+		{"one way refused", CharterPeriod{PeriodFrom: day(15, 5), PeriodTo: day(22, 5), BaseFromID: 10, BaseToID: 20}, []string{CheckInRuleOneWay}},
+		{"reversed", CharterPeriod{PeriodFrom: day(12, 6), PeriodTo: day(5, 6)}, []string{CheckInRuleInvalidPeriod}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := y.CheckPeriod(&tt.cp)
+			if len(vs) != len(tt.rules) {
+				t.Fatalf("got %d violations, want %d", len(vs), len(tt.rules))
+			}
+			for i, v := range vs {
+				if v.Rule != tt.rules[i] {
+					t.Errorf("got rule %s, want %s", v.Rule, tt.rules[i])
+				}
+			}
+		})
+	}
+
+	ir := &InfoRequest{PeriodFrom: &NausysDate{day(7, 6)}, PeriodTo: &NausysDate{day(14, 6)}}
+	var cie *CheckInError
+	if err := ir.ValidateCheckIn(&y); !errors.As(err, &cie) {
+		t.Fatalf("expected a check-in error, got %v", err)
+	}
+	if want := "yacht 1: check-in is not allowed on Monday, allowed days are Saturday; check-out is not allowed on Monday, allowed days are Saturday"; cie.Error() != want {
+		t.Errorf("got %q, want %q", cie.Error(), want)
+	}
+}
+
+func TestYachtSuggestPeriods(t *testing.T) {
+	var y Yacht
+	if err := json.Unmarshal([]byte(testCheckInYacht), &y); err != nil {
+		t.Fatal(err)
+	}
+
+	// Wednesday to Wednesday, the yacht only charters Saturday to Saturday.
+	pss := y.SuggestPeriods(&CharterPeriod{
+		PeriodFrom: time.Date(2021, 6, 9, 0, 0, 0, 0, time.UTC),
+		PeriodTo:   time.Date(2021, 6, 16, 0, 0, 0, 0, time.UTC),
+	}, 4, 2)
+
+	want := []struct {
+		from, to string
+		distance int
+	}{
+		{"12.06.2021", "19.06.2021", 6},
+		{"05.06.2021", "19.06.2021", 7},
+	}
+	if len(pss) != len(want) {
+		t.Fatalf("got %d suggestions, want %d", len(pss), len(want))
+	}
+
+	for i, ps := range pss {
+		if ps.PeriodFrom.Format("02.01.2006") != want[i].from || ps.PeriodTo.Format("02.01.2006") != want[i].to || ps.Distance != want[i].distance {
+			t.Errorf("suggestion %d: got %s - %s (%d), want %s - %s (%d)", i,
+				ps.PeriodFrom.Format("02.01.2006"), ps.PeriodTo.Format("02.01.2006"), ps.Distance,
+				want[i].from, want[i].to, want[i].distance)
+		}
+	}
+}
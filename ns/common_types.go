@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+	// Embedded so that NausysLocation does not depend on the host.
+	_ "time/tzdata"
 )
 
 // Response represents an API response.
@@ -44,12 +46,12 @@ type Period struct {
 
 // FreeYachtListResponse is a list of all free yachts available from Nausys.
 type FreeYachtListResponse struct {
-	Status       string      `json:"status,omitempty"`
-	ErrorCode    int         `json:"errorCode,omitempty"`
-	PeriodFrom   *NausysDate `json:"periodFrom,omitempty"`
-	PeriodTo     *NausysDate `json:"periodTo,omitempty"`
-	FreeYachts   []FreeYacht `json:"freeYachts,omitempty"`
-	PaymentPlans PaymentPlan `json:"paymentPlans,omitempty"`
+	Status       string        `json:"status,omitempty"`
+	ErrorCode    int           `json:"errorCode,omitempty"`
+	PeriodFrom   *NausysDate   `json:"periodFrom,omitempty"`
+	PeriodTo     *NausysDate   `json:"periodTo,omitempty"`
+	FreeYachts   []FreeYacht   `json:"freeYachts,omitempty"`
+	PaymentPlans []PaymentPlan `json:"paymentPlans,omitempty"`
 }
 
 // FreeYacht is a free yacht object with timeframe of which it is free and locations.
@@ -65,19 +67,19 @@ type FreeYacht struct {
 // YachtReservationPriceInfo contains the information on the reservation price of a yacht including discounts
 // and currency.
 type YachtReservationPriceInfo struct {
-	PriceListPrice           string      `json:"priceListPrice,omitempty"`
-	ClientPrice              string      `json:"clientPrice,omitempty"`
+	PriceListPrice           Money       `json:"priceListPrice,omitempty"`
+	ClientPrice              Money       `json:"clientPrice,omitempty"`
 	Currency                 string      `json:"currency,omitempty"`
-	DepositAmount            string      `json:"depositAmount,omitempty"`
-	DepositWhenInsuredAmount string      `json:"depositWhenInsuredAmount,omitempty"`
+	DepositAmount            Money       `json:"depositAmount,omitempty"`
+	DepositWhenInsuredAmount Money       `json:"depositWhenInsuredAmount,omitempty"`
 	Discounts                []*Discount `json:"discounts,omitempty"`
 }
 
 // Discount describes the discount and type applied to an item.
 type Discount struct {
-	DiscountedItemId int64   `json:"discountedItemId,omitempty"`
-	Amount           float32 `json:"amount,omitempty"`
-	Type             string  `json:"type,omitempty"`
+	DiscountedItemId int64  `json:"discountedItemId,omitempty"`
+	Amount           Money  `json:"amount,omitempty"`
+	Type             string `json:"type,omitempty"`
 }
 
 // PaymentPlan describes a payment plan that can be used for payment of a yacht reservation.
@@ -86,12 +88,12 @@ type PaymentPlan struct {
 	Percentage int         `json:"percentage,omitempty"`
 }
 
-// BookingPaymentPlan describes a payment plan that was created as part of a bookingd
+// BookingPaymentPlan describes an installment of the payment plan created as part of a booking.
 type BookingPaymentPlan struct {
 	ID                      int64           `json:"id,omitempty"`
 	Date                    *NausysDate     `json:"date,omitempty"`
-	Amount                  string          `json:"amount,omitempty"`
-	AmountPaymentCurrency   string          `json:"amountPaymentCurrency,omitempty"`
+	Amount                  Money           `json:"amount,omitempty"`
+	AmountPaymentCurrency   Money           `json:"amountPaymentCurrency,omitempty"`
 	Paid                    bool            `json:"paid,omitempty"`
 	OnlinePaymentLink       string          `json:"onlinePaymentLink,omitempty"`
 	OnlinePaymentValidUTill *NausysDateTime `json:"onlinePaymentValidUTill,omitempty"`
@@ -140,15 +142,15 @@ type OccupancyListResponse struct {
 
 // Reservation is a reservation object used in occupancy.
 type Reservation struct {
-	ID              int64       `json:"id,omitempty"`
-	YachtID         int64       `json:"yachtId,omitempty"`
-	LocationFromID  int64       `json:"locationFromId,omitempty"`
-	LocationToID    int64       `json:"locationToId,omitempty"`
-	ReservationType string      `json:"reservationType,omitempty"`
-	PeriodFrom      *NausysDate `json:"periodFrom,omitempty"`
-	CheckInTime     *NausysTime `json:"checkInTime,omitempty"`
-	PeriodTo        *NausysDate `json:"periodTo,omitempty"`
-	CheckOutTime    *NausysTime `json:"checkOutTime,omitempty"`
+	ID              int64           `json:"id,omitempty"`
+	YachtID         int64           `json:"yachtId,omitempty"`
+	LocationFromID  int64           `json:"locationFromId,omitempty"`
+	LocationToID    int64           `json:"locationToId,omitempty"`
+	ReservationType ReservationType `json:"reservationType,omitempty"`
+	PeriodFrom      *NausysDate     `json:"periodFrom,omitempty"`
+	CheckInTime     *NausysTime     `json:"checkInTime,omitempty"`
+	PeriodTo        *NausysDate     `json:"periodTo,omitempty"`
+	CheckOutTime    *NausysTime     `json:"checkOutTime,omitempty"`
 }
 
 // YachtListResponse is a response that contains a list of yacht objects
@@ -188,7 +190,7 @@ type Yacht struct {
 	MainPictureUrl              string           `json:"mainPictureUrl,omitempty"`
 	PicturesUrl                 []string         `json:"picturesUrl,omitempty"`
 	Commission                  float64          `json:"commission,omitempty"`
-	Deposit                     float64          `json:"deposit,omitempty"`
+	Deposit                     Money            `json:"deposit,omitempty"`
 	DepositCurrency             string           `json:"depositCurrency,omitempty"`
 	MaxDiscount                 float64          `json:"maxDiscount,omitempty"`
 	SeasonSpecificData          []YachtSeason    `json:"seasonSpecificData,omitempty"`
@@ -204,7 +206,7 @@ type Yacht struct {
 	NumberOfRudderBlades        int              `json:"numberOfRudderBlades,omitempty"`
 	EngineBuilderID             int64            `json:"engineBuilderId,omitempty"`
 	HullColor                   string           `json:"hullColor,omitempty"`
-	ThirdPartyInsuranceAmount   float64          `json:"thirdPartyInsuranceAmount,omitempty"`
+	ThirdPartyInsuranceAmount   Money            `json:"thirdPartyInsuranceAmount,omitempty"`
 	ThirdPartyInsuranceCurrency string           `json:"thirdPartyInsuranceCurrency,omitempty"`
 	CheckInPeriods              []CheckInPeriod  `json:"checkInPeriods,omitempty"`
 }
@@ -286,30 +288,66 @@ type InternationalText struct {
 	TextES string `json:"textES,omitempty"`
 }
 
+// Localized returns the translation for the given language code (e.g. "EN", "de"),
+// falling back to the English text when no translation is available.
+func (it InternationalText) Localized(lang string) string {
+	var t string
+	switch strings.ToUpper(lang) {
+	case "DE":
+		t = it.TextDE
+	case "HR":
+		t = it.TextHR
+	case "IT":
+		t = it.TextIT
+	case "SI":
+		t = it.TextSI
+	case "RU":
+		t = it.TextRU
+	case "CZ":
+		t = it.TextCZ
+	case "FR":
+		t = it.TextFR
+	case "PL":
+		t = it.TextPL
+	case "SK":
+		t = it.TextSK
+	case "NL":
+		t = it.TextNL
+	case "ES":
+		t = it.TextES
+	}
+
+	if t == "" {
+		t = it.TextEN
+	}
+
+	return t
+}
+
 // AdditionalYachtEquipment describes equipment that can be booked with a yacht
 type AdditionalYachtEquipment struct {
 	ID                        int64             `json:"id,omitempty"`
 	Quantity                  int               `json:"quantity,omitempty"`
-	Price                     string            `json:"price,omitempty"`
+	Price                     Money             `json:"price,omitempty"`
 	Currency                  string            `json:"currency,omitempty"`
 	EquipmentID               int64             `json:"equipmentId,omitempty"`
 	Comment                   InternationalText `json:"comment,omitempty"`
 	PriceMeasureID            int64             `json:"priceMeasureId,omitempty"`
 	CalculationType           string            `json:"calculationType,omitempty"`
 	Condition                 InternationalText `json:"condition,omitempty"`
-	Amount                    string            `json:"amount,omitempty"`
+	Amount                    Money             `json:"amount,omitempty"`
 	AmountIsPercentage        bool              `json:"amountIsPercentage,omitempty"`
 	PercentageCalculationType string            `json:"percentageCalculationType,omitempty"`
 	ValidForBases             []int64           `json:"validForBases,omitempty"`
-	MinimumPrice              string            `json:"minimumPrice,omitempty"`
+	MinimumPrice              Money             `json:"minimumPrice,omitempty"`
 }
 
 // Payment describes a payment object
 type Payment struct {
 	ID                    int64  `json:"id,omitempty"`
 	Date                  string `json:"date,omitempty"`
-	Amount                string `json:"amount,omitempty"`
-	AmountPaymentCurrency string `json:"amountPaymentCurrency,omitempty"`
+	Amount                Money  `json:"amount,omitempty"`
+	AmountPaymentCurrency Money  `json:"amountPaymentCurrency,omitempty"`
 	PaymentCurrency       string `json:"paymentCurrency,omitempty"`
 }
 
@@ -317,13 +355,13 @@ type Payment struct {
 type YachtService struct {
 	ID                        int64             `json:"id,omitempty"`
 	ServiceID                 int64             `json:"serviceId,omitempty"`
-	Price                     string            `json:"price,omitempty"`
+	Price                     Money             `json:"price,omitempty"`
 	Currency                  string            `json:"currency,omitempty"`
 	PriceMeasureID            int64             `json:"priceMeasureId,omitempty"`
 	CalculationType           string            `json:"calculationType,omitempty"`
 	Description               InternationalText `json:"description,omitempty"`
 	Obligatory                bool              `json:"obligatory,omitempty"`
-	Amount                    string            `json:"amount,omitempty"`
+	Amount                    Money             `json:"amount,omitempty"`
 	AmountIsPercentage        bool              `json:"amountIsPercentage,omitempty"`
 	PercentageCalculationType string            `json:"percentageCalculationType,omitempty"`
 	ValidPeriodFrom           string            `json:"validPeriodFrom,omitempty"`
@@ -331,7 +369,7 @@ type YachtService struct {
 	ValidMinPax               int               `json:"validMinPax,omitempty"`
 	ValidMaxPax               int               `json:"validMaxPax,omitempty"`
 	ValidForBases             []int64           `json:"validForBases,omitempty"`
-	MinimumPrice              string            `json:"minimumPrice,omitempty"`
+	MinimumPrice              Money             `json:"minimumPrice,omitempty"`
 }
 
 // YachtPrice describes a yacht price.
@@ -339,7 +377,7 @@ type YachtPrice struct {
 	ID         int64   `json:"id,omitempty"`
 	DateFrom   string  `json:"dateFrom,omitempty"`
 	DateTo     string  `json:"dateTo,omitempty"`
-	Price      float32 `json:"price,omitempty"`
+	Price      Money   `json:"price,omitempty"`
 	Currency   string  `json:"currency,omitempty"`
 	Type       string  `json:"type,omitempty"`
 	LocationID []int64 `json:"locationId,omitempty"`
@@ -372,7 +410,7 @@ type ReservationsList struct {
 type ReservationInfo struct {
 	ID                             int64                       `json:"id,omitempty"`
 	Uuid                           string                      `json:"uuid,omitempty"`
-	ReservationStatus              string                      `json:"reservationStatus,omitempty"`
+	ReservationStatus              ReservationStatus           `json:"reservationStatus,omitempty"`
 	WaitingForOption               bool                        `json:"waitingForOption,omitempty"`
 	YachtID                        int64                       `json:"yachtID,omitempty"`
 	BaseFromId                     int64                       `json:"baseFromId,omitempty"`
@@ -381,30 +419,171 @@ type ReservationInfo struct {
 	LocationToId                   int64                       `json:"locationToId,omitempty"`
 	PeriodFrom                     *NausysDateTime             `json:"periodFrom,omitempty"`
 	PeriodTo                       *NausysDateTime             `json:"periodTo,omitempty"`
-	OptionTill                     string                      `json:"optionTill,omitempty"`
+	OptionTill                     *NausysDateTime             `json:"optionTill,omitempty"`
 	Agency                         string                      `json:"agency,omitempty"`
 	AgencyVatID                    string                      `json:"agencyVATID,omitempty"`
 	Client                         *ClientInfo                 `json:"client,omitempty"`
 	Discounts                      []*Discount                 `json:"discounts,omitempty"`
 	AdditionalEquipment            []*AdditionalYachtEquipment `json:"additionalEquipment,omitempty"`
-	Services                       []*YachtsService            `json:"services,omitempty"`
-	PriceListPrice                 string                      `json:"priceListPrice,omitempty"`
-	AgencyPrice                    string                      `json:"agencyPrice,omitempty"`
-	ClientPrice                    string                      `json:"clientPrice,omitempty"`
+	Services                       []*YachtService             `json:"services,omitempty"`
+	PriceListPrice                 Money                       `json:"priceListPrice,omitempty"`
+	AgencyPrice                    Money                       `json:"agencyPrice,omitempty"`
+	ClientPrice                    Money                       `json:"clientPrice,omitempty"`
 	Currency                       string                      `json:"currency,omitempty"`
 	PaymentCurrency                string                      `json:"paymentCurrency,omitempty"`
 	LocalizedFinalPrice            string                      `json:"localizedFinalPrice,omitempty"`
-	OnlinePaymentAmount            string                      `json:"onlinePaymentAmount,omitempty"`
+	OnlinePaymentAmount            Money                       `json:"onlinePaymentAmount,omitempty"`
 	Approved                       bool                        `json:"approved,omitempty"`
 	CrewListLink                   string                      `json:"crewlistlink,omitempty"`
 	CreatedDate                    string                      `json:"createdDate,omitempty"`
-	PaymentPlan                    []*PaymentPlan              `json:"paymentPlan,omitempty"`
+	PaymentPlan                    []*BookingPaymentPlan       `json:"paymentPlan,omitempty"`
 	Payments                       []*Payment                  `json:"payments,omitempty"`
 	UseDepositPayment              bool                        `json:"useDepositPayment,omitempty"`
 	NumberOfPayments               int                         `json:"numberOfPayments,omitempty"`
 	OwnerBooking                   bool                        `json:"ownerBooking,omitempty"`
-	AgencyAdditionalDiscountAmount string                      `json:"agencyAdditionalDiscountAmount,omitempty"`
-	AgencyClientFinalPrice         string                      `json:"agencyClientFinalPrice,omitempty"`
+	AgencyAdditionalDiscountAmount Money                       `json:"agencyAdditionalDiscountAmount,omitempty"`
+	AgencyClientFinalPrice         Money                       `json:"agencyClientFinalPrice,omitempty"`
+}
+
+// EquipmentListResponse is a list of all equipment known to Nausys.
+type EquipmentListResponse struct {
+	Status    string      `json:"status,omitempty"`
+	ErrorCode int         `json:"errorCode,omitempty"`
+	Equipment []Equipment `json:"equipment,omitempty"`
+}
+
+// Equipment describes a single equipment catalogue entry.
+type Equipment struct {
+	ID         int64             `json:"id,omitempty"`
+	CategoryID int64             `json:"categoryId,omitempty"`
+	Name       InternationalText `json:"name,omitempty"`
+}
+
+// EquipmentCategoryListResponse is a list of all equipment categories known to Nausys.
+type EquipmentCategoryListResponse struct {
+	Status              string              `json:"status,omitempty"`
+	ErrorCode           int                 `json:"errorCode,omitempty"`
+	EquipmentCategories []EquipmentCategory `json:"equipmentCategories,omitempty"`
+}
+
+// EquipmentCategory describes a group of equipment entries.
+type EquipmentCategory struct {
+	ID   int64             `json:"id,omitempty"`
+	Name InternationalText `json:"name,omitempty"`
+}
+
+// ServiceListResponse is a list of all services known to Nausys.
+type ServiceListResponse struct {
+	Status    string    `json:"status,omitempty"`
+	ErrorCode int       `json:"errorCode,omitempty"`
+	Services  []Service `json:"services,omitempty"`
+}
+
+// Service describes a single service catalogue entry.
+type Service struct {
+	ID   int64             `json:"id,omitempty"`
+	Name InternationalText `json:"name,omitempty"`
+}
+
+// PriceMeasureListResponse is a list of all price measures known to Nausys.
+type PriceMeasureListResponse struct {
+	Status        string         `json:"status,omitempty"`
+	ErrorCode     int            `json:"errorCode,omitempty"`
+	PriceMeasures []PriceMeasure `json:"priceMeasures,omitempty"`
+}
+
+// PriceMeasure describes the unit a service or equipment price is expressed in.
+type PriceMeasure struct {
+	ID   int64             `json:"id,omitempty"`
+	Name InternationalText `json:"name,omitempty"`
+}
+
+// SeasonListResponse is a list of all seasons known to Nausys.
+type SeasonListResponse struct {
+	Status    string   `json:"status,omitempty"`
+	ErrorCode int      `json:"errorCode,omitempty"`
+	Seasons   []Season `json:"seasons,omitempty"`
+}
+
+// Season describes a charter company season and the dates it covers.
+type Season struct {
+	ID               int64       `json:"id,omitempty"`
+	CharterCompanyID int64       `json:"charterCompanyId,omitempty"`
+	Season           string      `json:"season,omitempty"`
+	DateFrom         *NausysDate `json:"dateFrom,omitempty"`
+	DateTo           *NausysDate `json:"dateTo,omitempty"`
+	DefaultSeason    bool        `json:"defaultSeason,omitempty"`
+	Locations        []int64     `json:"locations,omitempty"`
+}
+
+// SteeringTypeListResponse is a list of all steering types known to Nausys.
+type SteeringTypeListResponse struct {
+	Status        string         `json:"status,omitempty"`
+	ErrorCode     int            `json:"errorCode,omitempty"`
+	SteeringTypes []SteeringType `json:"steeringTypes,omitempty"`
+}
+
+// SteeringType describes the steering of a yacht (wheel, tiller...).
+type SteeringType struct {
+	ID   int64             `json:"id,omitempty"`
+	Name InternationalText `json:"name,omitempty"`
+}
+
+// SailTypeListResponse is a list of all sail types known to Nausys.
+type SailTypeListResponse struct {
+	Status    string     `json:"status,omitempty"`
+	ErrorCode int        `json:"errorCode,omitempty"`
+	SailTypes []SailType `json:"sailTypes,omitempty"`
+}
+
+// SailType describes a main sail or genoa type.
+type SailType struct {
+	ID   int64             `json:"id,omitempty"`
+	Name InternationalText `json:"name,omitempty"`
+}
+
+// FlagListResponse is a list of all yacht flags known to Nausys.
+type FlagListResponse struct {
+	Status    string `json:"status,omitempty"`
+	ErrorCode int    `json:"errorCode,omitempty"`
+	Flags     []Flag `json:"flags,omitempty"`
+}
+
+// Flag describes a yacht flag.
+type Flag struct {
+	ID   int64             `json:"id,omitempty"`
+	Name InternationalText `json:"name,omitempty"`
+}
+
+// CrewListResponse is the crew list of a booking as returned by Nausys.
+type CrewListResponse struct {
+	Status    string    `json:"status,omitempty"`
+	ErrorCode int       `json:"errorCode,omitempty"`
+	CrewList  *CrewList `json:"crewList,omitempty"`
+}
+
+// CrewList describes the skipper and crew members of a booking.
+type CrewList struct {
+	Skipper     *CrewMember   `json:"skipper,omitempty"`
+	CrewMembers []*CrewMember `json:"crewMembers,omitempty"`
+}
+
+// CrewMember describes a person on board with their identity document.
+type CrewMember struct {
+	ID             int64       `json:"id,omitempty"`
+	Name           string      `json:"name,omitempty"`
+	Surname        string      `json:"surname,omitempty"`
+	Gender         string      `json:"gender,omitempty"`
+	DateOfBirth    *NausysDate `json:"dateOfBirth,omitempty"`
+	PlaceOfBirth   string      `json:"placeOfBirth,omitempty"`
+	NationalityID  int64       `json:"nationalityId,omitempty"`
+	DocumentType   string      `json:"documentType,omitempty"`
+	DocumentNumber string      `json:"documentNumber,omitempty"`
+	DocumentExpiry *NausysDate `json:"documentExpiry,omitempty"`
+	Address        string      `json:"address,omitempty"`
+	Email          string      `json:"email,omitempty"`
+	Phone          string      `json:"phone,omitempty"`
+	SailingLicence string      `json:"sailingLicence,omitempty"`
 }
 
 // NausysDate allows to perform (un)marshal operations with JSON
@@ -414,11 +593,26 @@ type NausysDate struct {
 }
 
 // NausysDateTime allows to perform (un)marshal operations with JSON
-// on Nausys's date time formatted response objects.
+// on Nausys's date time formatted response objects. Nausys sends wall clock
+// times without an offset, they are read and written in NausysLocation.
 type NausysDateTime struct {
 	time.Time
 }
 
+// NausysLocation is the time zone of the date times sent by Nausys, such as
+// option expiry times. It defaults to Europe/Zagreb and should only be
+// changed before any request is made.
+var NausysLocation = mustLoadLocation("Europe/Zagreb")
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+
+	return loc
+}
+
 // NausysTime allows to (un)marshalling operations with JSON on
 // Nausys's time formatted response objects.
 type NausysTime struct {
@@ -447,7 +641,7 @@ func (d *NausysDate) UnmarshalJSON(b []byte) error {
 // MarshalJSON overrides the default marshal action
 // for the Time struct. Returns date as YYYY-MM-DD HH:ii:ss formatted string.
 func (dt *NausysDateTime) MarshalJSON() ([]byte, error) {
-	return json.Marshal(dt.Time.Format("02.01.2006 15:04"))
+	return json.Marshal(dt.Time.In(NausysLocation).Format("02.01.2006 15:04"))
 }
 
 // UnmarshalJSON overrides the default unmarshal action
@@ -455,7 +649,7 @@ func (dt *NausysDateTime) MarshalJSON() ([]byte, error) {
 func (dt *NausysDateTime) UnmarshalJSON(b []byte) error {
 	s := string(b)
 	s = strings.Trim(s, "\"")
-	t, err := time.Parse("02.01.2006 15:04", s)
+	t, err := time.ParseInLocation("02.01.2006 15:04", s, NausysLocation)
 	if err != nil {
 		return err
 	}
@@ -481,3 +675,125 @@ func (t *NausysTime) UnmarshalJSON(b []byte) error {
 	t.Time = ti
 	return nil
 }
+
+// The hooks below decode Nausys objects carrying amounts and stamp each
+// amount with the currency sent alongside it.
+
+// UnmarshalJSON decodes the price info and sets the currency of its amounts.
+func (p *YachtReservationPriceInfo) UnmarshalJSON(b []byte) error {
+	type alias YachtReservationPriceInfo
+	if err := json.Unmarshal(b, (*alias)(p)); err != nil {
+		return err
+	}
+
+	p.PriceListPrice.Currency = p.Currency
+	p.ClientPrice.Currency = p.Currency
+	p.DepositAmount.Currency = p.Currency
+	p.DepositWhenInsuredAmount.Currency = p.Currency
+
+	return nil
+}
+
+// UnmarshalJSON decodes the yacht and sets the currency of its amounts.
+func (y *Yacht) UnmarshalJSON(b []byte) error {
+	type alias Yacht
+	if err := json.Unmarshal(b, (*alias)(y)); err != nil {
+		return err
+	}
+
+	y.Deposit.Currency = y.DepositCurrency
+	y.ThirdPartyInsuranceAmount.Currency = y.ThirdPartyInsuranceCurrency
+
+	return nil
+}
+
+// UnmarshalJSON decodes the equipment and sets the currency of its amounts.
+func (e *AdditionalYachtEquipment) UnmarshalJSON(b []byte) error {
+	type alias AdditionalYachtEquipment
+	if err := json.Unmarshal(b, (*alias)(e)); err != nil {
+		return err
+	}
+
+	e.Price.Currency = e.Currency
+	e.MinimumPrice.Currency = e.Currency
+	if !e.AmountIsPercentage {
+		e.Amount.Currency = e.Currency
+	}
+
+	return nil
+}
+
+// UnmarshalJSON decodes the service and sets the currency of its amounts.
+func (s *YachtService) UnmarshalJSON(b []byte) error {
+	type alias YachtService
+	if err := json.Unmarshal(b, (*alias)(s)); err != nil {
+		return err
+	}
+
+	s.Price.Currency = s.Currency
+	s.MinimumPrice.Currency = s.Currency
+	if !s.AmountIsPercentage {
+		s.Amount.Currency = s.Currency
+	}
+
+	return nil
+}
+
+// UnmarshalJSON decodes the yacht price and sets its currency.
+func (p *YachtPrice) UnmarshalJSON(b []byte) error {
+	type alias YachtPrice
+	if err := json.Unmarshal(b, (*alias)(p)); err != nil {
+		return err
+	}
+
+	p.Price.Currency = p.Currency
+
+	return nil
+}
+
+// UnmarshalJSON decodes the payment and sets the currency of the amount paid
+// in the payment currency. The other amount is set by the reservation.
+func (p *Payment) UnmarshalJSON(b []byte) error {
+	type alias Payment
+	if err := json.Unmarshal(b, (*alias)(p)); err != nil {
+		return err
+	}
+
+	p.AmountPaymentCurrency.Currency = p.PaymentCurrency
+
+	return nil
+}
+
+// UnmarshalJSON decodes the reservation and sets the currency of its
+// amounts, including the ones of its payment plan and payments. The online
+// payment amount is in the payment currency.
+func (ri *ReservationInfo) UnmarshalJSON(b []byte) error {
+	type alias ReservationInfo
+	if err := json.Unmarshal(b, (*alias)(ri)); err != nil {
+		return err
+	}
+
+	ri.PriceListPrice.Currency = ri.Currency
+	ri.AgencyPrice.Currency = ri.Currency
+	ri.ClientPrice.Currency = ri.Currency
+	ri.AgencyAdditionalDiscountAmount.Currency = ri.Currency
+	ri.AgencyClientFinalPrice.Currency = ri.Currency
+	ri.OnlinePaymentAmount.Currency = ri.PaymentCurrency
+
+	for _, bpp := range ri.PaymentPlan {
+		if bpp == nil {
+			continue
+		}
+		bpp.Amount.Currency = ri.Currency
+		bpp.AmountPaymentCurrency.Currency = ri.PaymentCurrency
+	}
+
+	for _, p := range ri.Payments {
+		if p == nil {
+			continue
+		}
+		p.Amount.Currency = ri.Currency
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package ns
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Crew member identity document types.
+const (
+	DocumentTypePassport = "PASSPORT"
+	DocumentTypeIDCard   = "ID_CARD"
+)
+
+// CrewListRequest describes a request to get or save the crew list of a booking.
+type CrewListRequest struct {
+	Credentials *Credentials `json:"credentials,omitempty"`
+	ID          int64        `json:"id,omitempty"`
+	UUID        string       `json:"uuid,omitempty"`
+	CrewList    *CrewList    `json:"crewList,omitempty"`
+}
+
+// CrewListValidationError lists the problems found in a crew list.
+type CrewListValidationError struct {
+	Problems []string
+}
+
+// Error function complies with the error interface.
+func (e *CrewListValidationError) Error() string {
+	return fmt.Sprintf("invalid crew list: %s", strings.Join(e.Problems, "; "))
+}
+
+// Validate checks that the crew list has a skipper and that every person
+// on board has the fields Nausys requires. It returns a
+// *CrewListValidationError listing every problem found.
+func (cl *CrewList) Validate() error {
+	var problems []string
+
+	if cl.Skipper == nil {
+		problems = append(problems, "skipper is missing")
+	} else {
+		problems = append(problems, cl.Skipper.problems("skipper")...)
+	}
+
+	for i, cm := range cl.CrewMembers {
+		if cm == nil {
+			problems = append(problems, fmt.Sprintf("crew member %d is empty", i+1))
+			continue
+		}
+
+		problems = append(problems, cm.problems(fmt.Sprintf("crew member %d", i+1))...)
+	}
+
+	if len(problems) > 0 {
+		return &CrewListValidationError{Problems: problems}
+	}
+
+	return nil
+}
+
+func (cm *CrewMember) problems(who string) []string {
+	var p []string
+
+	missing := func(field string) {
+		p = append(p, fmt.Sprintf("%s: %s is required", who, field))
+	}
+
+	if strings.TrimSpace(cm.Name) == "" {
+		missing("name")
+	}
+	if strings.TrimSpace(cm.Surname) == "" {
+		missing("surname")
+	}
+	if cm.DateOfBirth == nil {
+		missing("date of birth")
+	}
+	if cm.NationalityID == 0 {
+		missing("nationality")
+	}
+	if strings.TrimSpace(cm.DocumentNumber) == "" {
+		missing("document number")
+	}
+
+	switch cm.DocumentType {
+	case "":
+		missing("document type")
+	case DocumentTypePassport, DocumentTypeIDCard:
+	default:
+		p = append(p, fmt.Sprintf("%s: unknown document type %q", who, cm.DocumentType))
+	}
+
+	return p
+}
+
+// GetCrewList gets the crew list of a booking.
+func (rsrv *ReservationService) GetCrewList(clr *CrewListRequest) (r *CrewListResponse, err error) {
+	clr.Credentials = &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/crewList", BookingURL)
+
+	req, err := rsrv.client.NewAPIRequest(http.MethodPost, target, clr)
+	if err != nil {
+		return
+	}
+
+	res, err := rsrv.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	err = checkForErrorResponse(res)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &r); err != nil {
+		return
+	}
+
+	return
+}
+
+// SaveCrewList validates and submits the crew list of a booking.
+func (rsrv *ReservationService) SaveCrewList(clr *CrewListRequest) (r *CrewListResponse, err error) {
+	if clr.CrewList == nil {
+		return nil, &CrewListValidationError{Problems: []string{"crew list is missing"}}
+	}
+
+	if err = clr.CrewList.Validate(); err != nil {
+		return
+	}
+
+	clr.Credentials = &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/saveCrewList", BookingURL)
+
+	req, err := rsrv.client.NewAPIRequest(http.MethodPost, target, clr)
+	if err != nil {
+		return
+	}
+
+	res, err := rsrv.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	err = checkForErrorResponse(res)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &r); err != nil {
+		return
+	}
+
+	return
+}
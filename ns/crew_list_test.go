@@ -0,0 +1,93 @@
+package ns
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCrewList_Validate(t *testing.T) {
+	person := func(name string) *CrewMember {
+		return &CrewMember{
+			Name:           name,
+			Surname:        "Horvat",
+			DateOfBirth:    &NausysDate{time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)},
+			NationalityID:  1,
+			DocumentType:   DocumentTypePassport,
+			DocumentNumber: "P123",
+		}
+	}
+
+	tests := []struct {
+		name string
+		cl   *CrewList
+		want []string
+	}{
+		{
+			"valid",
+			&CrewList{Skipper: person("Ivan"), CrewMembers: []*CrewMember{person("Ana")}},
+			nil,
+		},
+		{
+			"missing skipper",
+			&CrewList{CrewMembers: []*CrewMember{person("Ana")}},
+			[]string{"skipper is missing"},
+		},
+		{
+			"missing fields",
+			&CrewList{Skipper: &CrewMember{Name: " ", DocumentType: DocumentTypeIDCard}},
+			[]string{
+				"skipper: name is required",
+				"skipper: surname is required",
+				"skipper: date of birth is required",
+				"skipper: nationality is required",
+				"skipper: document number is required",
+			},
+		},
+		{
+			"missing document type",
+			&CrewList{Skipper: person("Ivan"), CrewMembers: []*CrewMember{func() *CrewMember {
+				cm := person("Ana")
+				cm.DocumentType = ""
+				return cm
+			}()}},
+			[]string{"crew member 1: document type is required"},
+		},
+		{
+			"unknown document type",
+			&CrewList{Skipper: func() *CrewMember {
+				cm := person("Ivan")
+				cm.DocumentType = "DRIVING_LICENCE"
+				return cm
+			}()},
+			[]string{`skipper: unknown document type "DRIVING_LICENCE"`},
+		},
+		{
+			"empty crew member",
+			&CrewList{Skipper: person("Ivan"), CrewMembers: []*CrewMember{person("Ana"), nil}},
+			[]string{"crew member 2 is empty"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cl.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var ve *CrewListValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("expected a *CrewListValidationError, got %v", err)
+			}
+
+			if fmt.Sprintf("%q", ve.Problems) != fmt.Sprintf("%q", tt.want) {
+				t.Errorf("got problems %q, want %q", ve.Problems, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package ns
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+// RateProvider provides exchange rates. Rate returns how many units of the
+// to currency one unit of the from currency is worth.
+type RateProvider interface {
+	Rate(from, to string) (*big.Rat, error)
+}
+
+// StaticRates is a RateProvider backed by a fixed table of rates against a
+// base currency. Rates between two other currencies are crossed through
+// the base currency.
+type StaticRates struct {
+	Base string
+	// Date is the day the rates were published, if known.
+	Date  time.Time
+	rates map[string]*big.Rat
+}
+
+// NewStaticRates returns a rate table where one unit of the base currency is
+// worth the given decimal amount of each currency, e.g. {"USD": "1.1856"}
+// against EUR.
+func NewStaticRates(base string, rates map[string]string) (*StaticRates, error) {
+	sr := &StaticRates{
+		Base:  strings.ToUpper(base),
+		rates: make(map[string]*big.Rat, len(rates)),
+	}
+
+	for c, r := range rates {
+		if err := sr.set(c, r); err != nil {
+			return nil, err
+		}
+	}
+
+	return sr, nil
+}
+
+func (sr *StaticRates) set(currency, rate string) error {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(rate))
+	if !ok || r.Sign() <= 0 {
+		return fmt.Errorf("invalid rate %q for %s", rate, currency)
+	}
+
+	sr.rates[strings.ToUpper(currency)] = r
+
+	return nil
+}
+
+// Rate returns how many units of the to currency one unit of the from
+// currency is worth.
+func (sr *StaticRates) Rate(from, to string) (*big.Rat, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	f, err := sr.baseRate(from)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := sr.baseRate(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Rat).Quo(t, f), nil
+}
+
+func (sr *StaticRates) baseRate(currency string) (*big.Rat, error) {
+	if currency == sr.Base {
+		return big.NewRat(1, 1), nil
+	}
+
+	r, ok := sr.rates[currency]
+	if !ok {
+		return nil, fmt.Errorf("no exchange rate for %s", currency)
+	}
+
+	return r, nil
+}
+
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// LoadECBRates reads exchange rates in the European Central Bank reference
+// rates XML format, against EUR. When the document holds several days, the
+// first one, which is the latest in ECB files, is used.
+func LoadECBRates(r io.Reader) (*StaticRates, error) {
+	var env ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&env); err != nil {
+		return nil, err
+	}
+
+	if len(env.Days) == 0 {
+		return nil, fmt.Errorf("no exchange rates found")
+	}
+
+	day := env.Days[0]
+	sr := &StaticRates{
+		Base:  "EUR",
+		rates: make(map[string]*big.Rat, len(day.Rates)),
+	}
+
+	if day.Time != "" {
+		t, err := time.Parse("2006-01-02", day.Time)
+		if err != nil {
+			return nil, err
+		}
+		sr.Date = t
+	}
+
+	for _, rate := range day.Rates {
+		if err := sr.set(rate.Currency, rate.Rate); err != nil {
+			return nil, err
+		}
+	}
+
+	return sr, nil
+}
+
+// LoadECBRatesFile reads a European Central Bank reference rates XML file.
+func LoadECBRatesFile(path string) (*StaticRates, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadECBRates(f)
+}
+
+// currencyDecimals lists the ISO 4217 currencies whose minor unit is not
+// a hundredth.
+var currencyDecimals = map[string]int{
+	"BHD": 3, "CLP": 0, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0,
+	"KWD": 3, "OMR": 3, "TND": 3, "UGX": 0, "VND": 0, "XAF": 0, "XOF": 0,
+}
+
+// CurrencyDecimals returns the number of decimals of the minor unit of a
+// currency, two unless listed otherwise by ISO 4217.
+func CurrencyDecimals(currency string) int {
+	if d, ok := currencyDecimals[strings.ToUpper(currency)]; ok {
+		return d
+	}
+
+	return 2
+}
+
+// Converter converts amounts into another currency.
+//
+// Each amount is multiplied by the exact rate and rounded half away from
+// zero to the minor unit of the target currency. Totals of converted price
+// breakdowns are the sums of their converted lines, so they may differ by a
+// few minor units from converting the original totals.
+type Converter struct {
+	rates RateProvider
+}
+
+// NewConverter returns a converter using the given rates.
+func NewConverter(rp RateProvider) *Converter {
+	return &Converter{rates: rp}
+}
+
+// Convert converts an amount into the given currency. Amounts without a
+// currency are returned unchanged.
+func (c *Converter) Convert(m Money, to string) (Money, error) {
+	if m.Currency == "" || strings.EqualFold(m.Currency, to) {
+		return m, nil
+	}
+
+	r, err := c.rates.Rate(m.Currency, to)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return m.MulRatRound(r, CurrencyDecimals(to)).WithCurrency(to), nil
+}
+
+// ConvertPriceInfo returns a copy of the price of a free yacht converted into
+// the given currency. Fixed discounts are converted, percentages are kept.
+func (c *Converter) ConvertPriceInfo(p *YachtReservationPriceInfo, to string) (cp *YachtReservationPriceInfo, err error) {
+	cp = &YachtReservationPriceInfo{Currency: to}
+
+	for _, f := range []struct {
+		src Money
+		dst *Money
+	}{
+		{p.PriceListPrice, &cp.PriceListPrice},
+		{p.ClientPrice, &cp.ClientPrice},
+		{p.DepositAmount, &cp.DepositAmount},
+		{p.DepositWhenInsuredAmount, &cp.DepositWhenInsuredAmount},
+	} {
+		if *f.dst, err = c.Convert(f.src.WithCurrency(p.Currency), to); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, d := range p.Discounts {
+		cd := *d
+		if cd.Type == DiscountTypeFixed {
+			if cd.Amount, err = c.Convert(d.Amount.WithCurrency(p.Currency), to); err != nil {
+				return nil, err
+			}
+		}
+		cp.Discounts = append(cp.Discounts, &cd)
+	}
+
+	return
+}
+
+// ConvertFreeYacht returns a copy of a free yacht with its price converted
+// into the given currency.
+func (c *Converter) ConvertFreeYacht(fy *FreeYacht, to string) (*FreeYacht, error) {
+	p, err := c.ConvertPriceInfo(&fy.Price, to)
+	if err != nil {
+		return nil, err
+	}
+
+	cfy := *fy
+	cfy.Price = *p
+
+	return &cfy, nil
+}
+
+// ConvertBreakdown returns a copy of a price breakdown with every line
+// converted into the given currency and the totals recomputed from them.
+func (c *Converter) ConvertBreakdown(pb *PriceBreakdown, to string) (*PriceBreakdown, error) {
+	cpb := &PriceBreakdown{}
+
+	for _, l := range pb.Lines {
+		cl := *l
+
+		var err error
+		if cl.Amount, err = c.Convert(l.Amount, to); err != nil {
+			return nil, err
+		}
+
+		if err = cpb.add(&cl); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if cpb.Charter, err = cpb.Base.Add(cpb.Discounts); err != nil {
+		return nil, err
+	}
+
+	return cpb, nil
+}
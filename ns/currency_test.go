@@ -0,0 +1,110 @@
+package ns
+
+import (
+	"strings"
+	"testing"
+)
+
+const ecbRates = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2021-06-04">
+			<Cube currency="USD" rate="1.2"/>
+			<Cube currency="JPY" rate="133.3"/>
+			<Cube currency="GBP" rate="0.8"/>
+		</Cube>
+		<Cube time="2021-06-03">
+			<Cube currency="USD" rate="1.1"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestLoadECBRates(t *testing.T) {
+	sr, err := LoadECBRates(strings.NewReader(ecbRates))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := sr.Date.Format("2006-01-02"); got != "2021-06-04" {
+		t.Errorf("got date %s, want 2021-06-04", got)
+	}
+
+	r, err := sr.Rate("USD", "GBP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.FloatString(4); got != "0.6667" {
+		t.Errorf("got USD/GBP %s, want 0.6667", got)
+	}
+
+	if _, err := sr.Rate("EUR", "CHF"); err == nil {
+		t.Error("expected an error for a missing rate")
+	}
+}
+
+func TestConverterConvert(t *testing.T) {
+	sr, err := NewStaticRates("EUR", map[string]string{"USD": "1.2", "JPY": "133.3", "KWD": "0.3621", "XXX": "0.00495"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewConverter(sr)
+
+	tests := []struct {
+		in   Money
+		to   string
+		want string
+	}{
+		{NewMoney(100000, "EUR"), "USD", "1200.00 USD"},
+		{NewMoney(1005, "EUR"), "JPY", "1340.00 JPY"},
+		{NewMoney(1000, "USD"), "EUR", "8.33 EUR"},
+		{NewMoney(-1000, "USD"), "EUR", "-8.33 EUR"},
+		{NewMoney(12345, "EUR"), "KWD", "44.701 KWD"},
+		{NewMoney(500, ""), "USD", "5.00"},
+		// 0.00495 is rounded once, not to 0.005 and then to 0.01.
+		{NewMoney(100, "EUR"), "XXX", "0.00 XXX"},
+	}
+
+	for _, tt := range tests {
+		got, err := c.Convert(tt.in, tt.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != tt.want {
+			t.Errorf("Convert(%s, %s) = %s, want %s", tt.in, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestConverterConvertBreakdown(t *testing.T) {
+	sr, _ := NewStaticRates("EUR", map[string]string{"USD": "1.23456"})
+	c := NewConverter(sr)
+
+	pb := &PriceBreakdown{}
+	for _, l := range []*PriceLine{
+		{Kind: PriceLineBase, Amount: NewMoney(100000, "EUR")},
+		{Kind: PriceLineDiscount, Amount: NewMoney(-10000, "EUR")},
+		{Kind: PriceLineService, Amount: NewMoney(1001, "EUR")},
+	} {
+		if err := pb.add(l); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cpb, err := c.ConvertBreakdown(pb, "USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for got, want := range map[string]string{
+		cpb.Base.String():      "1234.56 USD",
+		cpb.Discounts.String(): "-123.46 USD",
+		cpb.Charter.String():   "1111.10 USD",
+		cpb.Services.String():  "12.36 USD",
+		cpb.Total.String():     "1123.46 USD",
+	} {
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	}
+}
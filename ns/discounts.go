@@ -0,0 +1,187 @@
+package ns
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Discount types, also used for InfoRequest.AgencyClientDiscountAmountType.
+const (
+	DiscountTypePercentage = "PERCENTAGE"
+	DiscountTypeFixed      = "FIXED"
+)
+
+// ErrDiscountExceedsMax is returned when an agency client discount is higher
+// than the maximum discount allowed for the yacht.
+var ErrDiscountExceedsMax = errors.New("agency client discount exceeds the yacht maximum discount")
+
+// AppliedDiscount is a discount with the amount it took off the price.
+type AppliedDiscount struct {
+	Discount Discount
+	// Amount is the positive amount taken off the price.
+	Amount Money
+	// Price is the price once this discount and the previous ones applied.
+	Price Money
+}
+
+// DiscountResult is the outcome of applying discounts to a price.
+type DiscountResult struct {
+	Price      Money
+	Applied    []*AppliedDiscount
+	Total      Money
+	Discounted Money
+}
+
+// ApplyDiscounts applies discounts to a price in the order Nausys does.
+// Discounts are grouped by discounted item, in order of first appearance.
+// Within a group percentage discounts apply first, each on the price left by
+// the previous ones, then fixed discounts are taken off. The price never
+// goes below zero. A discount of any other type is an error.
+func ApplyDiscounts(price Money, ds []Discount) (dr *DiscountResult, err error) {
+	for _, d := range ds {
+		if d.Type != DiscountTypePercentage && d.Type != DiscountTypeFixed {
+			return nil, fmt.Errorf("unknown discount type %q for item %d", d.Type, d.DiscountedItemId)
+		}
+	}
+
+	dr = &DiscountResult{
+		Price:      price,
+		Total:      Money{Currency: price.Currency},
+		Discounted: price,
+	}
+
+	var order []int64
+	groups := make(map[int64][]Discount)
+	for _, d := range ds {
+		if _, ok := groups[d.DiscountedItemId]; !ok {
+			order = append(order, d.DiscountedItemId)
+		}
+		groups[d.DiscountedItemId] = append(groups[d.DiscountedItemId], d)
+	}
+
+	for _, item := range order {
+		for _, pass := range []bool{true, false} {
+			for _, d := range groups[item] {
+				if (d.Type == DiscountTypePercentage) != pass {
+					continue
+				}
+
+				amount := d.Amount.WithCurrency(price.Currency)
+				if pass {
+					amount = dr.Discounted.Percent(d.Amount)
+				}
+
+				if c, _ := amount.Cmp(dr.Discounted); c > 0 {
+					amount = dr.Discounted
+				}
+
+				if dr.Discounted, err = dr.Discounted.Sub(amount); err != nil {
+					return nil, err
+				}
+				if dr.Total, err = dr.Total.Add(amount); err != nil {
+					return nil, err
+				}
+
+				dr.Applied = append(dr.Applied, &AppliedDiscount{Discount: d, Amount: amount, Price: dr.Discounted})
+			}
+		}
+	}
+
+	return
+}
+
+// AgencyPricing splits a client price between the charter company and the
+// agency.
+type AgencyPricing struct {
+	ClientPrice Money
+	// Commission is the agency commission on the client price.
+	Commission Money
+	// ClientDiscount is the discount the agency gives its client, taken
+	// from its commission.
+	ClientDiscount Money
+	// AgencyClientPrice is what the client pays the agency.
+	AgencyClientPrice Money
+	// Net is what the agency pays the charter company.
+	Net Money
+	// AgencyEarnings is the commission left to the agency after the client discount.
+	AgencyEarnings Money
+}
+
+// CalculateAgencyPricing computes the agency commission and net price of a
+// yacht for the given client price, applying an optional agency client
+// discount, which is validated against the yacht maximum discount.
+func CalculateAgencyPricing(y *Yacht, clientPrice Money, discount *Money, discountType string) (ap *AgencyPricing, err error) {
+	ap = &AgencyPricing{
+		ClientPrice:    clientPrice,
+		Commission:     clientPrice.Percent(percentOf(y.Commission)),
+		ClientDiscount: Money{Currency: clientPrice.Currency},
+	}
+
+	if discount != nil {
+		if ap.ClientDiscount, err = agencyClientDiscount(y, clientPrice, *discount, discountType); err != nil {
+			return nil, err
+		}
+	}
+
+	if ap.Net, err = clientPrice.Sub(ap.Commission); err != nil {
+		return nil, err
+	}
+	if ap.AgencyClientPrice, err = clientPrice.Sub(ap.ClientDiscount); err != nil {
+		return nil, err
+	}
+	if ap.AgencyEarnings, err = ap.Commission.Sub(ap.ClientDiscount); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// ValidateAgencyDiscount checks the agency client discount of the request
+// against the maximum discount of the yacht for the given client price, so
+// that requests Nausys would reject are caught before calling CreateInfo.
+func (ir *InfoRequest) ValidateAgencyDiscount(y *Yacht, clientPrice Money) error {
+	if ir.AgencyClientDiscountAmount == nil {
+		return nil
+	}
+
+	_, err := agencyClientDiscount(y, clientPrice, *ir.AgencyClientDiscountAmount, ir.AgencyClientDiscountAmountType)
+
+	return err
+}
+
+// agencyClientDiscount returns the amount of an agency client discount,
+// failing when it is negative or above the maximum discount of the yacht.
+func agencyClientDiscount(y *Yacht, clientPrice, discount Money, discountType string) (Money, error) {
+	if discount.Sign() < 0 {
+		return Money{}, fmt.Errorf("agency client discount must not be negative, got %s", discount)
+	}
+
+	amount := discount.WithCurrency(clientPrice.Currency)
+	switch discountType {
+	case DiscountTypePercentage:
+		amount = clientPrice.Percent(discount)
+	case DiscountTypeFixed, "":
+		if discount.Currency != "" && discount.Currency != clientPrice.Currency {
+			return Money{}, &CurrencyMismatchError{A: discount.Currency, B: clientPrice.Currency}
+		}
+	default:
+		return Money{}, fmt.Errorf("unknown agency client discount type %q", discountType)
+	}
+
+	max := clientPrice.Percent(percentOf(y.MaxDiscount))
+	if c, err := amount.Cmp(max); err != nil {
+		return Money{}, err
+	} else if c > 0 {
+		return Money{}, fmt.Errorf("%w: %s is more than %s (%g%%)", ErrDiscountExceedsMax, amount, max, y.MaxDiscount)
+	}
+
+	return amount, nil
+}
+
+// percentOf converts a percentage sent as a number into a plain amount.
+func percentOf(f float64) Money {
+	m, _ := ParseMoneyDecimal(strconv.FormatFloat(f, 'f', -1, 64), "", '.')
+
+	return m
+}
@@ -0,0 +1,69 @@
+package ns
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApplyDiscounts(t *testing.T) {
+	price := NewMoney(200000, "EUR")
+	ds := []Discount{
+		{DiscountedItemId: 1, Type: DiscountTypeFixed, Amount: NewMoney(10000, "")},
+		{DiscountedItemId: 1, Type: DiscountTypePercentage, Amount: NewMoney(1000, "")},
+		{DiscountedItemId: 2, Type: DiscountTypePercentage, Amount: NewMoney(500, "")},
+	}
+
+	dr, err := ApplyDiscounts(price, ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2000 - 10% = 1800, - 100 = 1700, - 5% = 1615.
+	if got := dr.Discounted.String(); got != "1615.00 EUR" {
+		t.Errorf("discounted price = %s", got)
+	}
+
+	if got := dr.Total.String(); got != "385.00 EUR" {
+		t.Errorf("total discount = %s", got)
+	}
+
+	if dr.Applied[0].Discount.Type != DiscountTypePercentage {
+		t.Error("percentage discounts should apply before fixed ones")
+	}
+
+	for _, typ := range []string{"", "AMOUNT"} {
+		if _, err := ApplyDiscounts(price, []Discount{{DiscountedItemId: 1, Type: typ, Amount: NewMoney(10000, "")}}); err == nil {
+			t.Errorf("expected an error for discount type %q", typ)
+		}
+	}
+}
+
+func TestCalculateAgencyPricing(t *testing.T) {
+	y := &Yacht{Commission: 20, MaxDiscount: 10}
+	price := NewMoney(300000, "EUR")
+
+	pct := NewMoney(500, "")
+	ap, err := CalculateAgencyPricing(y, price, &pct, DiscountTypePercentage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := map[string]Money{
+		"600.00 EUR":  ap.Commission,
+		"150.00 EUR":  ap.ClientDiscount,
+		"2400.00 EUR": ap.Net,
+		"2850.00 EUR": ap.AgencyClientPrice,
+		"450.00 EUR":  ap.AgencyEarnings,
+	}
+	for want, m := range checks {
+		if m.String() != want {
+			t.Errorf("got %v, want %s", m, want)
+		}
+	}
+
+	tooMuch := NewMoney(40000, "EUR")
+	ir := &InfoRequest{AgencyClientDiscountAmount: &tooMuch, AgencyClientDiscountAmountType: DiscountTypeFixed}
+	if err := ir.ValidateAgencyDiscount(y, price); !errors.Is(err, ErrDiscountExceedsMax) {
+		t.Errorf("expected %v, got %v", ErrDiscountExceedsMax, err)
+	}
+}
@@ -0,0 +1,222 @@
+package ns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FleetReservation is an occupancy reservation tagged with the company
+// and year it was retrieved for.
+type FleetReservation struct {
+	CompanyID int64
+	Reservation
+}
+
+// OccupancyFailure reports a company and year combination whose
+// occupancy could not be retrieved.
+type OccupancyFailure struct {
+	CompanyID int64
+	Year      uint
+	Err       error
+}
+
+// Error function complies with the error interface.
+func (of *OccupancyFailure) Error() string {
+	return fmt.Sprintf("occupancy for company %d in %d: %v", of.CompanyID, of.Year, of.Err)
+}
+
+// FleetOccupancy is the merged occupancy of several companies over several
+// years. Reservations returned for more than one year are only kept once.
+// Reservations Nausys sent without an ID cannot be told apart and are all kept.
+type FleetOccupancy struct {
+	Reservations []*FleetReservation
+	ByCompany    map[int64][]*FleetReservation
+	ByYacht      map[int64][]*FleetReservation
+	// ByDate indexes reservations by every night they cover, keyed by the
+	// UTC midnight of the day. The check-out day is not included.
+	ByDate   map[time.Time][]*FleetReservation
+	Failures []*OccupancyFailure
+}
+
+// On returns the reservations occupying any yacht on the night of the given date.
+func (fo *FleetOccupancy) On(date time.Time) []*FleetReservation {
+	return fo.ByDate[time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)]
+}
+
+// YachtOccupiedOn reports whether the yacht is occupied on the night of the
+// given date, returning the reservation occupying it.
+func (fo *FleetOccupancy) YachtOccupiedOn(yachtID int64, date time.Time) (*FleetReservation, bool) {
+	for _, fr := range fo.On(date) {
+		if fr.YachtID == yachtID {
+			return fr, true
+		}
+	}
+
+	return nil, false
+}
+
+func (fo *FleetOccupancy) index(fr *FleetReservation) {
+	fo.ByCompany[fr.CompanyID] = append(fo.ByCompany[fr.CompanyID], fr)
+	fo.ByYacht[fr.YachtID] = append(fo.ByYacht[fr.YachtID], fr)
+
+	if fr.PeriodFrom == nil || fr.PeriodTo == nil {
+		return
+	}
+
+	for d := fr.PeriodFrom.Time; d.Before(fr.PeriodTo.Time); d = d.AddDate(0, 0, 1) {
+		fo.ByDate[d] = append(fo.ByDate[d], fr)
+	}
+}
+
+type occupancyJob struct {
+	companyID int64
+	year      uint
+	olr       *OccupancyListResponse
+	err       error
+}
+
+// Fleet retrieves the occupancy of every given company for every given year
+// using a pool of concurrent workers and merges the results. Failed company
+// and year combinations are listed in Failures and do not stop the others.
+// The returned error is only set when the context is done before every
+// combination was requested.
+//
+// Reservations are sorted by company, check-in date, yacht and ID, failures
+// by company and year, whatever order the requests completed in.
+func (ocs *OccupancyService) Fleet(ctx context.Context, companyIDs []int64, years []uint, workers int) (fo *FleetOccupancy, err error) {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	var all []*occupancyJob
+	for _, c := range companyIDs {
+		for _, y := range years {
+			all = append(all, &occupancyJob{companyID: c, year: y})
+		}
+	}
+
+	var (
+		wg   sync.WaitGroup
+		jobs = make(chan *occupancyJob)
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				j.olr, j.err = ocs.all(ctx, j.companyID, j.year)
+				if j.err == nil && j.olr != nil && j.olr.Status != "" && j.olr.Status != "OK" {
+					j.err = fmt.Errorf("invalid response from provider: %s", j.olr.Status)
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for i, j := range all {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+			for _, p := range all[i:] {
+				p.err = err
+			}
+			break
+		}
+
+		select {
+		case jobs <- j:
+		case <-ctx.Done():
+			err = ctx.Err()
+			for _, p := range all[i:] {
+				p.err = err
+			}
+			break dispatch
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return mergeOccupancy(all), err
+}
+
+// mergeOccupancy merges the results of the occupancy requests. Reservations
+// are only kept once when their ID is known, reservations without an ID are
+// all kept.
+func mergeOccupancy(jobs []*occupancyJob) *FleetOccupancy {
+	fo := &FleetOccupancy{
+		ByCompany: make(map[int64][]*FleetReservation),
+		ByYacht:   make(map[int64][]*FleetReservation),
+		ByDate:    make(map[time.Time][]*FleetReservation),
+	}
+
+	seen := make(map[int64]bool)
+	for _, j := range jobs {
+		if j.err != nil {
+			fo.Failures = append(fo.Failures, &OccupancyFailure{CompanyID: j.companyID, Year: j.year, Err: j.err})
+			continue
+		}
+
+		if j.olr == nil {
+			continue
+		}
+
+		for _, r := range j.olr.Reservations {
+			if r.ID != 0 {
+				if seen[r.ID] {
+					continue
+				}
+				seen[r.ID] = true
+			}
+			fo.Reservations = append(fo.Reservations, &FleetReservation{CompanyID: j.companyID, Reservation: r})
+		}
+	}
+
+	sort.SliceStable(fo.Reservations, func(i, j int) bool {
+		a, b := fo.Reservations[i], fo.Reservations[j]
+		switch {
+		case a.CompanyID != b.CompanyID:
+			return a.CompanyID < b.CompanyID
+		case a.PeriodFrom != nil && b.PeriodFrom != nil && !a.PeriodFrom.Time.Equal(b.PeriodFrom.Time):
+			return a.PeriodFrom.Time.Before(b.PeriodFrom.Time)
+		case (a.PeriodFrom == nil) != (b.PeriodFrom == nil):
+			return b.PeriodFrom == nil
+		case a.YachtID != b.YachtID:
+			return a.YachtID < b.YachtID
+		}
+		return a.ID < b.ID
+	})
+
+	sort.SliceStable(fo.Failures, func(i, j int) bool {
+		a, b := fo.Failures[i], fo.Failures[j]
+		if a.CompanyID != b.CompanyID {
+			return a.CompanyID < b.CompanyID
+		}
+		return a.Year < b.Year
+	})
+
+	for _, fr := range fo.Reservations {
+		fo.index(fr)
+	}
+
+	return fo
+}
+
+// AllCompanies retrieves the occupancy of every company returned by
+// CompanyService.All for the given years, see Fleet.
+func (ocs *OccupancyService) AllCompanies(ctx context.Context, years []uint, workers int) (fo *FleetOccupancy, err error) {
+	clr, err := ocs.client.Company.All()
+	if err != nil {
+		return
+	}
+
+	ids := make([]int64, 0, len(clr.Company))
+	for _, c := range clr.Company {
+		ids = append(ids, c.ID)
+	}
+
+	return ocs.Fleet(ctx, ids, years, workers)
+}
@@ -0,0 +1,133 @@
+package ns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newOccupancyServer(t *testing.T) *Client {
+	t.Helper()
+
+	occupancy := map[string]string{
+		"1/2021": `[
+			{"id":11,"yachtId":6,"periodFrom":"12.06.2021","periodTo":"19.06.2021"},
+			{"id":10,"yachtId":5,"periodFrom":"05.06.2021","periodTo":"12.06.2021"},
+			{"yachtId":7,"periodFrom":"05.06.2021","periodTo":"12.06.2021"},
+			{"yachtId":8,"periodFrom":"05.06.2021","periodTo":"12.06.2021"}
+		]`,
+		"1/2022": `[{"id":10,"yachtId":5,"periodFrom":"05.06.2021","periodTo":"12.06.2021"}]`,
+		"3/2021": `[{"id":30,"yachtId":9,"periodFrom":"01.05.2021","periodTo":"08.05.2021"}]`,
+		"3/2022": `[]`,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/charterCompanies") {
+			fmt.Fprint(w, `{"status":"OK","companies":[{"id":3},{"id":2},{"id":1}]}`)
+			return
+		}
+
+		key := r.URL.Path[strings.Index(r.URL.Path, "/occupancy/")+len("/occupancy/"):]
+		rs, ok := occupancy[key]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"OK","reservations":%s}`, rs)
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	c, _ := NewClient(nil)
+	c.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	return c
+}
+
+func TestOccupancyService_Fleet(t *testing.T) {
+	c := newOccupancyServer(t)
+
+	// Run several times, the merged order must not depend on scheduling.
+	for run := 0; run < 5; run++ {
+		fo, err := c.Occupancy.Fleet(context.Background(), []int64{3, 2, 1}, []uint{2022, 2021}, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+		for _, fr := range fo.Reservations {
+			got = append(got, fmt.Sprintf("%d/%d/%d", fr.CompanyID, fr.ID, fr.YachtID))
+		}
+
+		// Reservation 10 is only kept once, both reservations without an ID are kept.
+		want := "[1/10/5 1/0/7 1/0/8 1/11/6 3/30/9]"
+		if fmt.Sprint(got) != want {
+			t.Fatalf("run %d: got %v, want %s", run, got, want)
+		}
+
+		if len(fo.Failures) != 2 || fo.Failures[0].Year != 2021 || fo.Failures[1].Year != 2022 || fo.Failures[0].CompanyID != 2 {
+			t.Fatalf("run %d: unexpected failures %v", run, fo.Failures)
+		}
+	}
+}
+
+func TestOccupancyService_FleetIndexes(t *testing.T) {
+	c := newOccupancyServer(t)
+
+	fo, err := c.Occupancy.Fleet(context.Background(), []int64{1}, []uint{2021}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(fo.ByYacht[5]); n != 1 {
+		t.Errorf("expected 1 reservation for yacht 5, got %d", n)
+	}
+
+	if _, ok := fo.YachtOccupiedOn(5, time.Date(2021, 6, 11, 12, 0, 0, 0, time.UTC)); !ok {
+		t.Error("expected yacht 5 to be occupied on 11.06.2021")
+	}
+
+	// The check-out day is free.
+	if _, ok := fo.YachtOccupiedOn(5, time.Date(2021, 6, 12, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("expected yacht 5 to be free on 12.06.2021")
+	}
+}
+
+func TestOccupancyService_FleetCancelled(t *testing.T) {
+	c := newOccupancyServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fo, err := c.Occupancy.Fleet(ctx, []int64{1, 3}, []uint{2021}, 1)
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if len(fo.Failures) != 2 {
+		t.Errorf("expected every combination to fail, got %v", fo.Failures)
+	}
+}
+
+func TestOccupancyService_AllCompanies(t *testing.T) {
+	c := newOccupancyServer(t)
+
+	fo, err := c.Occupancy.AllCompanies(context.Background(), []uint{2021}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fo.Reservations) != 5 {
+		t.Errorf("expected 5 reservations, got %d", len(fo.Reservations))
+	}
+
+	if len(fo.Failures) != 1 || fo.Failures[0].CompanyID != 2 {
+		t.Errorf("expected company 2 to fail, got %v", fo.Failures)
+	}
+}
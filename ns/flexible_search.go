@@ -0,0 +1,177 @@
+package ns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// FlexibleSearch describes a charter whose check-in date may move, such as
+// "a week in July, give or take 3 days".
+type FlexibleSearch struct {
+	// PeriodFrom is the preferred check-in date.
+	PeriodFrom time.Time
+	// Nights is the charter duration, a week when zero.
+	Nights int
+	// FlexDays is how many days check-in may move before or after PeriodFrom.
+	FlexDays int
+	// CheckInDays are the weekdays check-in may happen on. When empty,
+	// charters lasting whole weeks follow the charter week convention and
+	// start on Saturday, other charters may start on any day.
+	CheckInDays []time.Weekday
+	YachtIds    []int64
+	PriceFrom   int
+	PriceTo     int
+	// PeriodsPerRequest is the number of periods sent in a single request,
+	// DefaultPeriodsPerRequest when zero.
+	PeriodsPerRequest int
+}
+
+// FlexibleOption gathers the free periods found for a yacht by a flexible
+// search.
+type FlexibleOption struct {
+	YachtID int64
+	// Best is the cheapest free period, the one closest to the preferred
+	// check-in date among equally priced ones.
+	Best *FreeYacht
+	// Periods are all the free periods of the yacht, cheapest first.
+	Periods []*FreeYacht
+}
+
+// Periods expands the search into its candidate charter periods, closest to
+// the preferred check-in date first.
+func (fs *FlexibleSearch) Periods() []*Period {
+	nights := fs.Nights
+	if nights <= 0 {
+		nights = 7
+	}
+
+	days := fs.CheckInDays
+	if len(days) == 0 && nights%7 == 0 {
+		days = []time.Weekday{time.Saturday}
+	}
+
+	from := dateOf(fs.PeriodFrom)
+
+	var ps []*Period
+	for i := 0; i <= 2*fs.FlexDays; i++ {
+		// 0, +1, -1, +2, -2...
+		offset := (i + 1) / 2
+		if i%2 == 0 {
+			offset = -offset
+		}
+
+		d := from.AddDate(0, 0, offset)
+		if len(days) > 0 && !containsWeekday(days, d.Weekday()) {
+			continue
+		}
+
+		ps = append(ps, &Period{
+			PeriodFrom: &NausysDate{d},
+			PeriodTo:   &NausysDate{d.AddDate(0, 0, nights)},
+		})
+	}
+
+	return ps
+}
+
+// SearchFlexible looks for the free yachts of every candidate period of the
+// search, sending the periods in batches, and returns the free periods of
+// each yacht, the yachts with the cheapest best period first. Prices in
+// different currencies are not converted, such yachts are ordered by
+// currency.
+func (as *AvailabilityService) SearchFlexible(ctx context.Context, fs *FlexibleSearch) ([]*FlexibleOption, error) {
+	ps := fs.Periods()
+	if len(ps) == 0 {
+		return nil, fmt.Errorf("no candidate period within %d days of %s", fs.FlexDays, fs.PeriodFrom.Format("02.01.2006"))
+	}
+
+	ar, err := as.chunkedAvailability(ctx, &FreeYachtRequest{
+		YachtIds:  fs.YachtIds,
+		PriceFrom: fs.PriceFrom,
+		PriceTo:   fs.PriceTo,
+		Periods:   ps,
+	}, AvailabilityYachtsPerRequest, fs.PeriodsPerRequest, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fys := make([]*FreeYacht, 0, len(ar.FreeYachts))
+	for i := range ar.FreeYachts {
+		fys = append(fys, &ar.FreeYachts[i])
+	}
+
+	return flexibleOptions(fys, dateOf(fs.PeriodFrom)), nil
+}
+
+// flexibleOptions groups free yachts by yacht and orders them by price.
+func flexibleOptions(fys []*FreeYacht, preferred time.Time) []*FlexibleOption {
+	byYacht := make(map[int64]*FlexibleOption)
+
+	var fos []*FlexibleOption
+	for _, fy := range fys {
+		fo, ok := byYacht[fy.YachtId]
+		if !ok {
+			fo = &FlexibleOption{YachtID: fy.YachtId}
+			byYacht[fy.YachtId] = fo
+			fos = append(fos, fo)
+		}
+		fo.Periods = append(fo.Periods, fy)
+	}
+
+	distance := func(fy *FreeYacht) time.Duration {
+		if fy.PeriodFrom == nil {
+			return 1<<63 - 1
+		}
+
+		d := fy.PeriodFrom.Time.Sub(preferred)
+		if d < 0 {
+			d = -d
+		}
+
+		return d
+	}
+
+	for _, fo := range fos {
+		sort.SliceStable(fo.Periods, func(i, j int) bool {
+			if c := comparePrices(fo.Periods[i], fo.Periods[j]); c != 0 {
+				return c < 0
+			}
+
+			return distance(fo.Periods[i]) < distance(fo.Periods[j])
+		})
+		fo.Best = fo.Periods[0]
+	}
+
+	sort.SliceStable(fos, func(i, j int) bool {
+		return comparePrices(fos[i].Best, fos[j].Best) < 0
+	})
+
+	return fos
+}
+
+// comparePrices compares the client prices of two free yachts, ordering
+// prices in different currencies by currency.
+func comparePrices(a, b *FreeYacht) int {
+	c, err := a.Price.ClientPrice.Cmp(b.Price.ClientPrice)
+	if err == nil {
+		return c
+	}
+
+	if a.Price.Currency < b.Price.Currency {
+		return -1
+	}
+
+	return 1
+}
+
+func containsWeekday(days []time.Weekday, wd time.Weekday) bool {
+	for _, d := range days {
+		if d == wd {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+package ns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlexibleSearch_Periods(t *testing.T) {
+	fs := &FlexibleSearch{PeriodFrom: time.Date(2021, 7, 5, 0, 0, 0, 0, time.UTC), FlexDays: 5}
+
+	var got []string
+	for _, p := range fs.Periods() {
+		got = append(got, p.PeriodFrom.Format("02.01.2006")+"-"+p.PeriodTo.Format("02.01.2006"))
+	}
+
+	want := fmt.Sprint([]string{"03.07.2021-10.07.2021", "10.07.2021-17.07.2021"})
+	if fmt.Sprint(got) != want {
+		t.Errorf("got %v, want %s", got, want)
+	}
+
+	fs.Nights, fs.FlexDays = 3, 1
+	if n := len(fs.Periods()); n != 3 {
+		t.Errorf("expected any check-in day for short charters, got %d periods", n)
+	}
+}
+
+func TestAvailabilityService_SearchFlexible(t *testing.T) {
+	prices := map[string]string{
+		"03.07.2021": `{"yachtId":1,"periodFrom":"03.07.2021","periodTo":"10.07.2021","price":{"clientPrice":1000,"currency":"EUR"}},
+			{"yachtId":2,"periodFrom":"03.07.2021","periodTo":"10.07.2021","price":{"clientPrice":950,"currency":"EUR"}}`,
+		"10.07.2021": `{"yachtId":1,"periodFrom":"10.07.2021","periodTo":"17.07.2021","price":{"clientPrice":900,"currency":"EUR"}}`,
+	}
+
+	var requests int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		var arq FreeYachtRequest
+		if err := json.NewDecoder(r.Body).Decode(&arq); err != nil || len(arq.Periods) != 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprintf(w, `{"status":"OK","freeYachts":[%s]}`, prices[arq.Periods[0].PeriodFrom.Format("02.01.2006")])
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c, _ := NewClient(nil)
+	c.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	fos, err := c.Availability.SearchFlexible(context.Background(), &FlexibleSearch{
+		PeriodFrom:        time.Date(2021, 7, 5, 0, 0, 0, 0, time.UTC),
+		FlexDays:          5,
+		PeriodsPerRequest: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests := atomic.LoadInt32(&requests); requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+
+	if len(fos) != 2 || fos[0].YachtID != 1 || fos[1].YachtID != 2 {
+		t.Fatalf("unexpected options order: %+v", fos)
+	}
+
+	if got := fos[0].Best.PeriodFrom.Format("02.01.2006"); got != "10.07.2021" {
+		t.Errorf("expected the cheapest period as best, got %s", got)
+	}
+
+	if len(fos[0].Periods) != 2 || len(fos[1].Periods) != 1 {
+		t.Errorf("unexpected periods: %d and %d", len(fos[0].Periods), len(fos[1].Periods))
+	}
+}
@@ -0,0 +1,110 @@
+package ns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// GroupBookResult is the outcome of a group booking, Results follow the
+// order of the requests.
+type GroupBookResult struct {
+	Results []*BookResult
+	// Booked is set when every yacht of the group was booked.
+	Booked bool
+}
+
+// BookGroup reserves several yachts atomically, e.g. for flotillas. Options
+// are placed on all requested yachts concurrently and are only converted to
+// bookings once every option succeeded.
+//
+// When any option fails the successful ones are released. When converting to
+// bookings fails for any yacht, the bookings already made are sent to storno
+// and the remaining options are released, so the group ends up either fully
+// booked or not reserved at all. opts applies to every yacht, OptionOnly is
+// ignored.
+func (rsrv *ReservationService) BookGroup(ctx context.Context, irs []*InfoRequest, opts *BookOptions) (gbr *GroupBookResult, err error) {
+	o := BookOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	o.OptionOnly = true
+
+	gbr = &GroupBookResult{
+		Results: make([]*BookResult, len(irs)),
+	}
+	errs := make([]error, len(irs))
+
+	var wg sync.WaitGroup
+	for i, ir := range irs {
+		wg.Add(1)
+		go func(i int, ir *InfoRequest) {
+			defer wg.Done()
+			gbr.Results[i], errs[i] = rsrv.Book(ctx, ir, &o)
+		}(i, ir)
+	}
+	wg.Wait()
+
+	if err = groupError(irs, errs); err != nil {
+		rsrv.releaseGroup(gbr, errs)
+		return
+	}
+
+	for i, br := range gbr.Results {
+		wg.Add(1)
+		go func(i int, br *BookResult) {
+			defer wg.Done()
+			booking, err := rsrv.BookingFrom(br.Reservation)
+			br.record(BookStepBooking, booking, err)
+			errs[i] = err
+		}(i, br)
+	}
+	wg.Wait()
+
+	if err = groupError(irs, errs); err != nil {
+		rsrv.releaseGroup(gbr, nil)
+		return
+	}
+
+	gbr.Booked = true
+
+	return
+}
+
+// releaseGroup cancels every reservation of the group that is still held.
+// Results whose workflow failed are skipped, Book already rolled them back.
+func (rsrv *ReservationService) releaseGroup(gbr *GroupBookResult, failed []error) {
+	var wg sync.WaitGroup
+	for i, br := range gbr.Results {
+		if failed != nil && failed[i] != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(br *BookResult) {
+			defer wg.Done()
+			r, err := rsrv.Release(br.Reservation)
+			br.record(BookStepRollback, r, err)
+			if err == nil {
+				br.RolledBack = true
+			}
+		}(br)
+	}
+	wg.Wait()
+}
+
+func groupError(irs []*InfoRequest, errs []error) error {
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("yacht %d: %v", irs[i].YachtID, err))
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("group booking failed for %d of %d yachts: %s", len(failed), len(irs), strings.Join(failed, "; "))
+}
@@ -0,0 +1,95 @@
+package ns
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReservationService_BookGroup(t *testing.T) {
+	f, c := newFakeNausys(t)
+
+	gbr, err := c.Reservation.BookGroup(context.Background(), []*InfoRequest{{YachtID: 1}, {YachtID: 2}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !gbr.Booked {
+		t.Error("expected the group to be booked")
+	}
+	for i, br := range gbr.Results {
+		if br.Reservation.State() != ReservationStatusBooking {
+			t.Errorf("result %d: expected a booking, got %s", i, br.Reservation.State())
+		}
+	}
+
+	if n := f.count("cancelOption") + f.count("stornoBooking"); n != 0 {
+		t.Errorf("expected nothing to be released, got %d", n)
+	}
+}
+
+func TestReservationService_BookGroupOptionFailed(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.reject = func(endpoint string, yachtID int64) int {
+		if endpoint == "createOption" && yachtID == 2 {
+			return 110
+		}
+		return 0
+	}
+
+	irs := []*InfoRequest{{YachtID: 1}, {YachtID: 2}, {YachtID: 3}}
+	gbr, err := c.Reservation.BookGroup(context.Background(), irs, nil)
+	if err == nil {
+		t.Fatal("expected the group booking to fail")
+	}
+
+	if gbr.Booked {
+		t.Error("expected the group not to be booked")
+	}
+
+	for _, i := range []int{0, 2} {
+		br := gbr.Results[i]
+		if !br.RolledBack || f.get(br.Reservation.ID).State() != ReservationStatusCancelled {
+			t.Errorf("result %d: expected the option to be released", i)
+		}
+	}
+
+	if br := gbr.Results[1]; br.RolledBack || br.Reservation.State() != ReservationStatusInfo {
+		t.Errorf("expected the failed yacht to keep its info reservation, got %s", br.Reservation.State())
+	}
+
+	if n := f.count("cancelOption"); n != 2 {
+		t.Errorf("expected 2 cancellations, got %d", n)
+	}
+	if n := f.count("createBooking"); n != 0 {
+		t.Errorf("expected no booking to be created, got %d", n)
+	}
+}
+
+func TestReservationService_BookGroupBookingFailed(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.reject = func(endpoint string, yachtID int64) int {
+		if endpoint == "createBooking" && yachtID == 2 {
+			return 130
+		}
+		return 0
+	}
+
+	gbr, err := c.Reservation.BookGroup(context.Background(), []*InfoRequest{{YachtID: 1}, {YachtID: 2}}, nil)
+	if err == nil {
+		t.Fatal("expected the group booking to fail")
+	}
+
+	for i, br := range gbr.Results {
+		if !br.RolledBack || f.get(br.Reservation.ID).State() != ReservationStatusCancelled {
+			t.Errorf("result %d: expected the reservation to be released", i)
+		}
+	}
+
+	// The completed booking goes to storno, the remaining option is released.
+	if n := f.count("stornoBooking"); n != 1 {
+		t.Errorf("expected 1 storno, got %d", n)
+	}
+	if n := f.count("cancelOption"); n != 1 {
+		t.Errorf("expected 1 cancellation, got %d", n)
+	}
+}
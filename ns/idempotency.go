@@ -0,0 +1,263 @@
+package ns
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Idempotent operation names.
+const (
+	IdempotentCreateInfo    = "createInfo"
+	IdempotentCreateOption  = "createOption"
+	IdempotentCreateBooking = "createBooking"
+)
+
+// ErrIdempotencyKeyReused is returned when a key already recorded for an
+// operation is used for a different one.
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused for a different operation")
+
+// ErrNoReservation is returned when an idempotent call succeeded without
+// returning a reservation, its outcome is left unknown.
+var ErrNoReservation = errors.New("no reservation returned")
+
+// IdempotencyRecord ties a caller supplied key to the reservation produced
+// by an operation. Completed is false while the outcome of the call is unknown.
+type IdempotencyRecord struct {
+	Key           string    `json:"key"`
+	Operation     string    `json:"operation"`
+	ReservationID int64     `json:"reservationId,omitempty"`
+	UUID          string    `json:"uuid,omitempty"`
+	Completed     bool      `json:"completed"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// IdempotencyStore persists idempotency records. Get returns a nil record
+// and no error when the key is unknown.
+type IdempotencyStore interface {
+	Get(key string) (*IdempotencyRecord, error)
+	Put(rec *IdempotencyRecord) error
+}
+
+// MemoryIdempotencyStore is an in-memory IdempotencyStore.
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+}
+
+// NewMemoryIdempotencyStore returns an empty in-memory store.
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		records: make(map[string]IdempotencyRecord),
+	}
+}
+
+// Get returns the record stored for the key.
+func (ms *MemoryIdempotencyStore) Get(key string) (*IdempotencyRecord, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	rec, ok := ms.records[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &rec, nil
+}
+
+// Put stores the record under its key.
+func (ms *MemoryIdempotencyStore) Put(rec *IdempotencyRecord) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.records[rec.Key] = *rec
+
+	return nil
+}
+
+// IdempotentReservations wraps the reservation calls that create or convert
+// reservations so that they can be retried safely with the same key.
+//
+// Before a call is issued its key is recorded, and once it succeeds the
+// resulting reservation is recorded too. When a key is seen again the
+// reservation is first reconciled with GetReservation: if the earlier call
+// did take effect its reservation is returned instead of calling again.
+//
+// Calls sharing a key are serialized within an IdempotentReservations, calls
+// made through different instances or processes sharing a store are not.
+type IdempotentReservations struct {
+	reservations *ReservationService
+	store        IdempotencyStore
+	mu           sync.Mutex
+	locks        map[string]*keyLock
+}
+
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewIdempotentReservations returns an idempotency layer over the reservation
+// service using the given store.
+func NewIdempotentReservations(rsrv *ReservationService, store IdempotencyStore) *IdempotentReservations {
+	return &IdempotentReservations{
+		reservations: rsrv,
+		store:        store,
+		locks:        make(map[string]*keyLock),
+	}
+}
+
+// CreateInfo creates an info reservation unless one was already created with
+// the key. An info whose creation outcome is unknown is created again, infos
+// expire on their own.
+func (ir *IdempotentReservations) CreateInfo(key string, req *InfoRequest) (*ReservationInfo, error) {
+	return ir.do(key, IdempotentCreateInfo, 0, "", func() (*ReservationInfo, error) {
+		return ir.reservations.CreateInfo(req)
+	})
+}
+
+// CreateOption turns a reservation into an option unless this was already
+// done with the key.
+func (ir *IdempotentReservations) CreateOption(key string, req *OptionBookingRequest) (*ReservationInfo, error) {
+	return ir.do(key, IdempotentCreateOption, req.ID, req.UUID, func() (*ReservationInfo, error) {
+		return ir.reservations.CreateOption(req)
+	})
+}
+
+// CreateBooking turns a reservation into a booking unless this was already
+// done with the key.
+func (ir *IdempotentReservations) CreateBooking(key string, req *OptionBookingRequest) (*ReservationInfo, error) {
+	return ir.do(key, IdempotentCreateBooking, req.ID, req.UUID, func() (*ReservationInfo, error) {
+		return ir.reservations.CreateBooking(req)
+	})
+}
+
+func (ir *IdempotentReservations) do(key, op string, id int64, uuid string, call func() (*ReservationInfo, error)) (*ReservationInfo, error) {
+	unlock := ir.lock(key)
+	defer unlock()
+
+	rec, err := ir.store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec != nil {
+		if rec.Operation != op {
+			return nil, ErrIdempotencyKeyReused
+		}
+
+		ri, err := ir.reconcile(rec)
+		if err != nil || ri != nil {
+			return ri, err
+		}
+	} else {
+		rec = &IdempotencyRecord{
+			Key:           key,
+			Operation:     op,
+			ReservationID: id,
+			UUID:          uuid,
+			CreatedAt:     time.Now(),
+		}
+		if err := ir.store.Put(rec); err != nil {
+			return nil, err
+		}
+	}
+
+	ri, err := call()
+	if err != nil {
+		return nil, err
+	}
+	if ri == nil || ri.ID == 0 {
+		return nil, ErrNoReservation
+	}
+
+	rec.ReservationID = ri.ID
+	rec.UUID = ri.Uuid
+	rec.Completed = true
+	if err := ir.store.Put(rec); err != nil {
+		return ri, fmt.Errorf("recording idempotency key %q: %w", key, err)
+	}
+
+	return ri, nil
+}
+
+// lock holds the lock of the key until the returned function is called.
+func (ir *IdempotentReservations) lock(key string) func() {
+	ir.mu.Lock()
+	kl, ok := ir.locks[key]
+	if !ok {
+		kl = &keyLock{}
+		ir.locks[key] = kl
+	}
+	kl.refs++
+	ir.mu.Unlock()
+
+	kl.mu.Lock()
+
+	return func() {
+		kl.mu.Unlock()
+
+		ir.mu.Lock()
+		if kl.refs--; kl.refs == 0 {
+			delete(ir.locks, key)
+		}
+		ir.mu.Unlock()
+	}
+}
+
+// reconcile looks up the reservation of a known key. It returns the
+// reservation when the recorded operation already took effect and nil when
+// the call has to be issued again.
+func (ir *IdempotentReservations) reconcile(rec *IdempotencyRecord) (*ReservationInfo, error) {
+	if rec.ReservationID == 0 {
+		return nil, nil
+	}
+
+	rl, err := ir.reservations.GetReservation(&ReservationsRequest{
+		Reservations:          []int64{rec.ReservationID},
+		IncludeWaitingOptions: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ri := range rl.Reservations {
+		if ri.ID != rec.ReservationID {
+			continue
+		}
+
+		if !rec.Completed && !idempotentEffective(rec.Operation, ri.State()) {
+			return nil, nil
+		}
+
+		if !rec.Completed {
+			rec.UUID = ri.Uuid
+			rec.Completed = true
+			if err := ir.store.Put(rec); err != nil {
+				return ri, err
+			}
+		}
+
+		return ri, nil
+	}
+
+	if rec.Completed {
+		return nil, fmt.Errorf("reservation %d recorded for idempotency key %q not found", rec.ReservationID, rec.Key)
+	}
+
+	return nil, nil
+}
+
+// idempotentEffective reports whether a reservation status shows that the
+// operation took effect.
+func idempotentEffective(op string, s ReservationStatus) bool {
+	switch op {
+	case IdempotentCreateOption:
+		return s == ReservationStatusOption || s == ReservationStatusWaitingOption || s == ReservationStatusBooking
+	case IdempotentCreateBooking:
+		return s == ReservationStatusBooking
+	}
+
+	return false
+}
@@ -0,0 +1,139 @@
+package ns
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestIdempotentReservations_CreateOption(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.add(&ReservationInfo{ID: 1, Uuid: "uuid-1", ReservationStatus: ReservationStatusInfo})
+
+	store := NewMemoryIdempotencyStore()
+	ir := NewIdempotentReservations(c.Reservation, store)
+
+	ri, err := ir.CreateOption("k", &OptionBookingRequest{ID: 1, UUID: "uuid-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ri.ID != 1 || ri.State() != ReservationStatusOption {
+		t.Fatalf("unexpected reservation %+v", ri)
+	}
+
+	rec, _ := store.Get("k")
+	if rec == nil || !rec.Completed || rec.ReservationID != 1 || rec.UUID != "uuid-1" {
+		t.Errorf("unexpected record %+v", rec)
+	}
+
+	// A retry after success returns the reservation without calling again.
+	ri, err = ir.CreateOption("k", &OptionBookingRequest{ID: 1, UUID: "uuid-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ri.ID != 1 {
+		t.Errorf("expected reservation 1, got %d", ri.ID)
+	}
+	if n := f.count("createOption"); n != 1 {
+		t.Errorf("expected 1 option call, got %d", n)
+	}
+
+	if _, err := ir.CreateBooking("k", &OptionBookingRequest{ID: 1}); err != ErrIdempotencyKeyReused {
+		t.Errorf("expected %v, got %v", ErrIdempotencyKeyReused, err)
+	}
+}
+
+func TestIdempotentReservations_UnknownOutcome(t *testing.T) {
+	tests := []struct {
+		name   string
+		status ReservationStatus
+		calls  int
+	}{
+		{"took effect", ReservationStatusOption, 0},
+		{"did not take effect", ReservationStatusInfo, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeNausys(t)
+			f.add(&ReservationInfo{ID: 1, Uuid: "uuid-1", ReservationStatus: tt.status})
+
+			// The previous attempt recorded the key but never learned its outcome.
+			store := NewMemoryIdempotencyStore()
+			store.Put(&IdempotencyRecord{Key: "k", Operation: IdempotentCreateOption, ReservationID: 1})
+
+			ri, err := NewIdempotentReservations(c.Reservation, store).CreateOption("k", &OptionBookingRequest{ID: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ri.State() != ReservationStatusOption {
+				t.Errorf("expected an option, got %s", ri.State())
+			}
+
+			if n := f.count("createOption"); n != tt.calls {
+				t.Errorf("expected %d option calls, got %d", tt.calls, n)
+			}
+
+			if rec, _ := store.Get("k"); !rec.Completed || rec.UUID != "uuid-1" {
+				t.Errorf("expected the record to be completed, got %+v", rec)
+			}
+		})
+	}
+}
+
+func TestIdempotentReservations_Rejected(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.add(&ReservationInfo{ID: 1, ReservationStatus: ReservationStatusInfo})
+	f.reject = func(string, int64) int { return 110 }
+
+	store := NewMemoryIdempotencyStore()
+	ir := NewIdempotentReservations(c.Reservation, store)
+
+	if _, err := ir.CreateOption("k", &OptionBookingRequest{ID: 1}); err == nil {
+		t.Fatal("expected the rejection to be returned")
+	}
+
+	if rec, _ := store.Get("k"); rec == nil || rec.Completed || rec.ReservationID != 1 {
+		t.Errorf("expected the outcome to stay unknown, got %+v", rec)
+	}
+
+	// The retry goes through once Nausys accepts it.
+	f.reject = nil
+	ri, err := ir.CreateOption("k", &OptionBookingRequest{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ri.State() != ReservationStatusOption {
+		t.Errorf("expected an option, got %s", ri.State())
+	}
+}
+
+func TestIdempotentReservations_Concurrent(t *testing.T) {
+	f, c := newFakeNausys(t)
+	ir := NewIdempotentReservations(c.Reservation, NewMemoryIdempotencyStore())
+
+	ids := make([]int64, 5)
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if ri, err := ir.CreateInfo("k", &InfoRequest{YachtID: 5}); err == nil {
+				ids[i] = ri.ID
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if n := f.count("createInfo"); n != 1 {
+		t.Errorf("expected 1 info call, got %d", n)
+	}
+	for i, id := range ids {
+		if id != ids[0] || id == 0 {
+			t.Errorf("call %d: expected reservation %d, got %d", i, ids[0], id)
+		}
+	}
+
+	if len(ir.locks) != 0 {
+		t.Errorf("expected key locks to be released, got %d", len(ir.locks))
+	}
+}
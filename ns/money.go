@@ -0,0 +1,420 @@
+package ns
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// moneyScale is the number of fractional digits kept by Money.
+const moneyScale = 4
+
+var moneyUnit = int64(math.Pow10(moneyScale))
+
+// Money is an exact decimal amount in a currency identified by its ISO 4217
+// code. Amounts are kept with four fractional digits, results of
+// multiplications and divisions are rounded half away from zero to that
+// precision.
+//
+// Money decoded from Nausys carries the currency found next to it in the
+// response. Money without a currency is used for plain amounts such as
+// percentages. The zero value is zero without a currency and can be added to
+// any amount.
+type Money struct {
+	units    int64
+	Currency string
+}
+
+// CurrencyMismatchError is returned by operations that would mix currencies.
+type CurrencyMismatchError struct {
+	A, B string
+}
+
+// Error function complies with the error interface.
+func (e *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("cannot mix currencies %q and %q", e.A, e.B)
+}
+
+// NewMoney returns the given number of minor units, e.g. cents, in the currency.
+func NewMoney(minor int64, currency string) Money {
+	return Money{units: minor * moneyUnit / 100, Currency: currency}
+}
+
+// ParseMoney parses a decimal amount. Decimal points and decimal commas are
+// both accepted, as are thousands separators made of dots, commas, spaces
+// or apostrophes: "1234.5", "1,234.50" and "1.234,50" are all valid.
+//
+// A single separator followed by exactly three digits, as in "1.250", could
+// be either and is rejected, use ParseMoneyDecimal for such amounts.
+func ParseMoney(s, currency string) (Money, error) {
+	return ParseMoneyDecimal(s, currency, 0)
+}
+
+// ParseMoneyDecimal parses a decimal amount whose decimal separator is
+// known, '.' or ','. The other one is taken as a thousands separator. A zero
+// decimal separator guesses it as ParseMoney does.
+func ParseMoneyDecimal(s, currency string, decimal byte) (Money, error) {
+	units, err := parseUnits(s, decimal)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{units: units, Currency: currency}, nil
+}
+
+func parseUnits(s string, decimal byte) (int64, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "", "'", "").Replace(s)
+
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	// When both dots and commas are used, the last one is the decimal
+	// separator. A single kind of separator is a decimal separator when
+	// it appears once and a thousands separator otherwise.
+	dot, comma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	sep := byte(0)
+	switch {
+	case decimal != 0:
+		if strings.IndexByte(s, decimal) >= 0 {
+			sep = decimal
+		}
+	case dot >= 0 && comma >= 0:
+		if dot > comma {
+			sep = '.'
+		} else {
+			sep = ','
+		}
+	case dot >= 0:
+		if strings.Count(s, ".") == 1 {
+			sep = '.'
+		}
+	case comma >= 0:
+		if strings.Count(s, ",") == 1 {
+			sep = ','
+		}
+	}
+
+	// "1.250" reads as 1250 or 1.25 depending on the locale, "0.250" and
+	// "1.2500" do not.
+	if decimal == 0 && (dot < 0) != (comma < 0) && sep != 0 {
+		i := strings.LastIndexByte(s, sep)
+		if len(s)-i == 4 && s[:i] != "" && s[:i] != "0" {
+			return 0, fmt.Errorf("ambiguous amount %q: %q may be a decimal or a thousands separator", orig, sep)
+		}
+	}
+
+	intPart, fracPart := s, ""
+	if sep != 0 {
+		i := strings.LastIndexByte(s, sep)
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	intPart = strings.NewReplacer(".", "", ",", "").Replace(intPart)
+
+	if intPart == "" && fracPart == "" {
+		return 0, fmt.Errorf("invalid amount %q", orig)
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	for _, r := range intPart + fracPart {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid amount %q", orig)
+		}
+	}
+
+	i, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", orig, err)
+	}
+
+	// Round the fraction half away from zero to the kept precision.
+	round := false
+	if len(fracPart) > moneyScale {
+		round = fracPart[moneyScale] >= '5'
+		fracPart = fracPart[:moneyScale]
+	}
+	fracPart += strings.Repeat("0", moneyScale-len(fracPart))
+
+	f, err := strconv.ParseInt(fracPart, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", orig, err)
+	}
+
+	if i > (math.MaxInt64-f)/moneyUnit {
+		return 0, fmt.Errorf("amount %q out of range", orig)
+	}
+
+	units := i*moneyUnit + f
+	if round {
+		units++
+	}
+	if neg {
+		units = -units
+	}
+
+	return units, nil
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of the amount.
+func (m Money) Sign() int {
+	switch {
+	case m.units < 0:
+		return -1
+	case m.units > 0:
+		return 1
+	}
+
+	return 0
+}
+
+// Neg returns the amount with its sign flipped.
+func (m Money) Neg() Money {
+	return Money{units: -m.units, Currency: m.Currency}
+}
+
+// Abs returns the absolute value of the amount.
+func (m Money) Abs() Money {
+	if m.units < 0 {
+		return m.Neg()
+	}
+
+	return m
+}
+
+// WithCurrency returns the same amount in the given currency, without any
+// conversion.
+func (m Money) WithCurrency(currency string) Money {
+	return Money{units: m.units, Currency: currency}
+}
+
+func (m Money) currencyWith(o Money) (string, error) {
+	switch {
+	case m.Currency == o.Currency:
+		return m.Currency, nil
+	case m.Currency == "" && m.units == 0:
+		return o.Currency, nil
+	case o.Currency == "" && o.units == 0:
+		return m.Currency, nil
+	}
+
+	return "", &CurrencyMismatchError{A: m.Currency, B: o.Currency}
+}
+
+// Add returns the sum of both amounts, failing when their currencies differ.
+func (m Money) Add(o Money) (Money, error) {
+	c, err := m.currencyWith(o)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{units: m.units + o.units, Currency: c}, nil
+}
+
+// Sub returns the difference of both amounts, failing when their
+// currencies differ.
+func (m Money) Sub(o Money) (Money, error) {
+	return m.Add(o.Neg())
+}
+
+// Cmp compares both amounts, failing when their currencies differ.
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := m.currencyWith(o); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.units < o.units:
+		return -1, nil
+	case m.units > o.units:
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+// Mul returns the amount multiplied by an integer factor.
+func (m Money) Mul(n int64) Money {
+	return Money{units: m.units * n, Currency: m.Currency}
+}
+
+// MulFrac returns the amount multiplied by num/den.
+func (m Money) MulFrac(num, den int64) Money {
+	return Money{units: mulDiv(m.units, num, den), Currency: m.Currency}
+}
+
+// Percent returns the given percentage of the amount, pct being a plain
+// amount such as 12.5 for 12.5%.
+func (m Money) Percent(pct Money) Money {
+	return Money{units: mulDiv(m.units, pct.units, 100*moneyUnit), Currency: m.Currency}
+}
+
+// MulRat returns the amount multiplied by an exact rational factor, such as
+// an exchange rate.
+func (m Money) MulRat(r *big.Rat) Money {
+	return m.MulRatRound(r, moneyScale)
+}
+
+// MulRatRound returns the amount multiplied by an exact rational factor and
+// rounded half away from zero to the given number of decimal places. The
+// exact product is rounded once, unlike MulRat followed by Round.
+func (m Money) MulRatRound(r *big.Rat, places int) Money {
+	step := int64(1)
+	if places < moneyScale {
+		step = int64(math.Pow10(moneyScale - places))
+	}
+
+	n := new(big.Int).Mul(big.NewInt(m.units), r.Num())
+	d := new(big.Int).Mul(r.Denom(), big.NewInt(step))
+
+	return Money{units: mulDivBig(n, d) * step, Currency: m.Currency}
+}
+
+// Ratio returns m divided by o as a plain amount, failing when their
+// currencies differ or o is zero.
+func (m Money) Ratio(o Money) (Money, error) {
+	if _, err := m.currencyWith(o); err != nil {
+		return Money{}, err
+	}
+
+	if o.units == 0 {
+		return Money{}, fmt.Errorf("division by zero")
+	}
+
+	return Money{units: mulDiv(m.units, moneyUnit, o.units)}, nil
+}
+
+// Round returns the amount rounded half away from zero to the given number
+// of decimal places.
+func (m Money) Round(places int) Money {
+	if places >= moneyScale {
+		return m
+	}
+
+	step := int64(math.Pow10(moneyScale - places))
+
+	return Money{units: mulDiv(m.units, 1, step) * step, Currency: m.Currency}
+}
+
+// Float64 returns the amount as a floating point number, for display only.
+func (m Money) Float64() float64 {
+	return float64(m.units) / float64(moneyUnit)
+}
+
+// Amount returns the amount as a decimal string with at least two decimals.
+func (m Money) Amount() string {
+	u := m.units
+	sign := ""
+	if u < 0 {
+		sign = "-"
+		u = -u
+	}
+
+	frac := fmt.Sprintf("%0*d", moneyScale, u%moneyUnit)
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) < 2 {
+		frac += strings.Repeat("0", 2-len(frac))
+	}
+
+	return fmt.Sprintf("%s%d.%s", sign, u/moneyUnit, frac)
+}
+
+// String returns the amount followed by its currency, if any.
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Amount()
+	}
+
+	return m.Amount() + " " + m.Currency
+}
+
+// MarshalJSON encodes the amount as a decimal string, the currency is
+// carried by the surrounding Nausys objects.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Amount())
+}
+
+// UnmarshalJSON decodes an amount sent either as a JSON number or as a,
+// possibly locale formatted, string. Unlike ParseMoney, a string with a
+// single separator is never ambiguous. The currency is left untouched.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	s, decimal := string(b), byte(0)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+
+		// A lone separator is the decimal one, as written by Amount and
+		// sent by Nausys: "1.250" is 1.25, not ambiguous.
+		if strings.Count(s, ".")+strings.Count(s, ",") == 1 {
+			decimal = s[strings.IndexAny(s, ".,")]
+		}
+	} else {
+		// Numeric fields always use a decimal point.
+		decimal = '.'
+
+		if strings.ContainsAny(s, "eE") {
+			// Exponent notation, only seen on numeric fields.
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return err
+			}
+			s = strconv.FormatFloat(f, 'f', -1, 64)
+		}
+	}
+
+	units, err := parseUnits(s, decimal)
+	if err != nil {
+		return err
+	}
+
+	m.units = units
+
+	return nil
+}
+
+// mulDiv returns a*b/c rounded half away from zero.
+func mulDiv(a, b, c int64) int64 {
+	return mulDivBig(new(big.Int).Mul(big.NewInt(a), big.NewInt(b)), big.NewInt(c))
+}
+
+// mulDivBig returns n/d rounded half away from zero.
+func mulDivBig(n, d *big.Int) int64 {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	r.Abs(r).Mul(r, big.NewInt(2))
+	if r.Cmp(new(big.Int).Abs(d)) >= 0 {
+		if n.Sign()*d.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return q.Int64()
+}
@@ -0,0 +1,165 @@
+package ns
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		err  bool
+	}{
+		{"1234.5", "1234.50", false},
+		{"1,234.50", "1234.50", false},
+		{"1.234,50", "1234.50", false},
+		{"1 234,5", "1234.50", false},
+		{"1'234'567.89", "1234567.89", false},
+		{"1.234.567", "1234567.00", false},
+		{"-12,3", "-12.30", false},
+		{"0.00005", "0.0001", false},
+		{"", "0.00", false},
+		{"12a", "", true},
+		{"-", "", true},
+		{"1.250", "", true},
+		{"1,250", "", true},
+		{"-2.500", "", true},
+		{"0.250", "0.25", false},
+		{"1.2500", "1.25", false},
+		{"1,25", "1.25", false},
+		{"1.250,5", "1250.50", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			m, err := ParseMoney(tt.in, "EUR")
+			if tt.err {
+				if err == nil {
+					t.Errorf("expected an error, got %v", m)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := m.Amount(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMoneyDecimal(t *testing.T) {
+	tests := []struct {
+		in      string
+		decimal byte
+		want    string
+	}{
+		{"1.250", '.', "1.25"},
+		{"1.250", ',', "1250.00"},
+		{"1,250", ',', "1.25"},
+		{"1,250", '.', "1250.00"},
+		{"1.234.567,8", ',', "1234567.80"},
+		{"1250", ',', "1250.00"},
+	}
+
+	for _, tt := range tests {
+		m, err := ParseMoneyDecimal(tt.in, "EUR", tt.decimal)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.in, err)
+			continue
+		}
+
+		if got := m.Amount(); got != tt.want {
+			t.Errorf("%s with %q: got %s, want %s", tt.in, tt.decimal, got, tt.want)
+		}
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a, _ := ParseMoney("100.10", "EUR")
+	b, _ := ParseMoney("0.20", "EUR")
+	usd, _ := ParseMoney("1", "USD")
+	pct, _ := ParseMoney("12.5", "")
+
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "100.30 EUR" {
+		t.Errorf("unexpected sum %v (%v)", sum, err)
+	}
+
+	if _, err := a.Add(usd); !errors.As(err, new(*CurrencyMismatchError)) {
+		t.Errorf("expected a currency mismatch, got %v", err)
+	}
+
+	if got := a.Percent(pct).Round(2).Amount(); got != "12.51" {
+		t.Errorf("unexpected percentage %s", got)
+	}
+
+	if got := a.MulFrac(1, 3).Amount(); got != "33.3667" {
+		t.Errorf("unexpected fraction %s", got)
+	}
+
+	var zero Money
+	if s, err := zero.Add(usd); err != nil || s.Currency != "USD" {
+		t.Errorf("zero value should add to any currency, got %v (%v)", s, err)
+	}
+}
+
+func TestMoney_UnmarshalJSON(t *testing.T) {
+	var ri ReservationInfo
+	err := json.Unmarshal([]byte(`{
+		"currency": "EUR",
+		"paymentCurrency": "HRK",
+		"clientPrice": "1.250,00",
+		"agencyPrice": 1100.5,
+		"priceListPrice": 1.125,
+		"onlinePaymentAmount": "9300",
+		"agencyClientFinalPrice": "1.250",
+		"paymentPlan": [{"amount": "625", "amountPaymentCurrency": "4650"}]
+	}`), &ri)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := map[string]Money{
+		"1250.00 EUR": ri.ClientPrice,
+		"1100.50 EUR": ri.AgencyPrice,
+		"1.125 EUR":   ri.PriceListPrice,
+		"9300.00 HRK": ri.OnlinePaymentAmount,
+		"1.25 EUR":    ri.AgencyClientFinalPrice,
+		"625.00 EUR":  ri.PaymentPlan[0].Amount,
+		"4650.00 HRK": ri.PaymentPlan[0].AmountPaymentCurrency,
+	}
+	for want, m := range checks {
+		if m.String() != want {
+			t.Errorf("got %v, want %s", m, want)
+		}
+	}
+}
+
+func TestMoney_JSONRoundTrip(t *testing.T) {
+	for _, in := range []string{"12.125", "1250.5", "-0.001", "1234567.8901"} {
+		m, err := ParseMoneyDecimal(in, "EUR", '.')
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got Money
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("%s: %v", b, err)
+		}
+
+		if got.Amount() != m.Amount() {
+			t.Errorf("%s: got %s, want %s", b, got.Amount(), m.Amount())
+		}
+	}
+}
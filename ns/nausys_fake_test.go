@@ -0,0 +1,186 @@
+package ns
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sync"
+	"testing"
+)
+
+// fakeNausys is an in-memory stand-in for the Nausys booking endpoints.
+type fakeNausys struct {
+	mu           sync.Mutex
+	nextID       int64
+	reservations map[int64]*ReservationInfo
+	calls        map[string]int
+
+	// reject makes an endpoint answer with a Nausys error code for a yacht,
+	// zero lets the call through.
+	reject func(endpoint string, yachtID int64) int
+	// outage makes an endpoint answer with a server error for a yacht.
+	outage func(endpoint string, yachtID int64) bool
+	// waitingOptions creates options waiting for another option.
+	waitingOptions bool
+	// needsApproval creates options that are not approved yet.
+	needsApproval bool
+	// onCreate is called for every info reservation created, before it is sent.
+	onCreate func(ri *ReservationInfo)
+	// onPoll is called for every reservation returned by the reservations
+	// endpoint, before it is sent.
+	onPoll func(ri *ReservationInfo)
+	// dropFromPolls leaves reservations out of the reservations endpoint.
+	dropFromPolls map[int64]bool
+}
+
+func newFakeNausys(t *testing.T) (*fakeNausys, *Client) {
+	t.Helper()
+
+	f := &fakeNausys{
+		nextID:        100,
+		reservations:  make(map[int64]*ReservationInfo),
+		calls:         make(map[string]int),
+		dropFromPolls: make(map[int64]bool),
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(ts.Close)
+
+	c, _ := NewClient(nil)
+	c.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	return f, c
+}
+
+func (f *fakeNausys) add(ri *ReservationInfo) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c := *ri
+	f.reservations[ri.ID] = &c
+}
+
+func (f *fakeNausys) get(id int64) *ReservationInfo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ri, ok := f.reservations[id]
+	if !ok {
+		return nil
+	}
+	c := *ri
+
+	return &c
+}
+
+func (f *fakeNausys) count(endpoint string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls[endpoint]
+}
+
+func (f *fakeNausys) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	endpoint := path.Base(r.URL.Path)
+	f.calls[endpoint]++
+
+	var (
+		ir  InfoRequest
+		obr OptionBookingRequest
+		rr  ReservationsRequest
+	)
+
+	var err error
+	switch endpoint {
+	case "createInfo":
+		err = json.NewDecoder(r.Body).Decode(&ir)
+	case "reservations":
+		err = json.NewDecoder(r.Body).Decode(&rr)
+	default:
+		err = json.NewDecoder(r.Body).Decode(&obr)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	yachtID := ir.YachtID
+	target := f.reservations[obr.ID]
+	if obr.ID == 0 && obr.UUID != "" {
+		for _, ri := range f.reservations {
+			if ri.Uuid == obr.UUID {
+				target = ri
+			}
+		}
+	}
+	if target != nil {
+		yachtID = target.YachtID
+	}
+
+	if f.outage != nil && f.outage(endpoint, yachtID) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	if f.reject != nil {
+		if code := f.reject(endpoint, yachtID); code != 0 {
+			fmt.Fprintf(w, `{"status":"ERROR","errorCode":%d}`, code)
+			return
+		}
+	}
+
+	switch endpoint {
+	case "reservations":
+		rl := &ReservationsList{Status: "OK"}
+		for _, id := range rr.Reservations {
+			if ri, ok := f.reservations[id]; ok && !f.dropFromPolls[id] {
+				if f.onPoll != nil {
+					f.onPoll(ri)
+				}
+				c := *ri
+				rl.Reservations = append(rl.Reservations, &c)
+			}
+		}
+		json.NewEncoder(w).Encode(rl)
+		return
+	case "createInfo":
+		f.nextID++
+		target = &ReservationInfo{
+			ID:                f.nextID,
+			Uuid:              fmt.Sprintf("uuid-%d", f.nextID),
+			YachtID:           ir.YachtID,
+			ReservationStatus: ReservationStatusInfo,
+		}
+		f.reservations[target.ID] = target
+		if f.onCreate != nil {
+			f.onCreate(target)
+		}
+	default:
+		if target == nil {
+			fmt.Fprint(w, `{"status":"ERROR","errorCode":404}`)
+			return
+		}
+
+		switch endpoint {
+		case "createOption":
+			target.ReservationStatus = ReservationStatusOption
+			target.WaitingForOption = obr.CreateWaitingOption && f.waitingOptions
+			target.Approved = !f.needsApproval
+		case "createBooking":
+			target.ReservationStatus = ReservationStatusBooking
+		case "cancelOption", "stornoBooking":
+			target.ReservationStatus = ReservationStatusCancelled
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	}
+
+	json.NewEncoder(w).Encode(target)
+}
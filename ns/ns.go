@@ -33,6 +33,7 @@ type Client struct {
 	BaseURL      *url.URL
 	userAgent    string
 	client       *http.Client
+	limiter      *rateLimiter
 	common       service // Reuse a single struct instead of allocating one for each service on the heap.
 	Availability *AvailabilityService
 	Offers       *OffersService
@@ -40,6 +41,7 @@ type Client struct {
 	Company      *CompanyService
 	Yacht        *YachtsService
 	Reservation  *ReservationService
+	Catalogue    *CatalogueService
 }
 
 // NewClient returns a new Nausys HTTP API client.
@@ -71,9 +73,22 @@ func NewClient(baseClient *http.Client) (nausys *Client, err error) {
 	nausys.Company = (*CompanyService)(&nausys.common)
 	nausys.Yacht = (*YachtsService)(&nausys.common)
 	nausys.Reservation = (*ReservationService)(&nausys.common)
+	nausys.Catalogue = (*CatalogueService)(&nausys.common)
 	return
 }
 
+// SetRateLimit caps the number of requests per second sent by the client,
+// a value of zero or less removes the limit. It must not be called while
+// requests are in flight.
+func (c *Client) SetRateLimit(rps int) {
+	if rps <= 0 {
+		c.limiter = nil
+		return
+	}
+
+	c.limiter = newRateLimiter(rps)
+}
+
 type service struct {
 	client *Client
 }
@@ -115,6 +130,12 @@ func (c *Client) NewAPIRequest(method string, uri string, body interface{}) (req
 // Do sends an API request and returns the API response or returned as an
 // error if an API error has occurred.
 func (c *Client) Do(req *http.Request) (*Response, error) {
+	if c.limiter != nil {
+		if err := c.limiter.wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package ns
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,6 +14,10 @@ type OccupancyService service
 // All Provides all reservations for specified company in specified
 // year regardless who made them.
 func (ocs *OccupancyService) All(companyID int64, year uint) (olr *OccupancyListResponse, err error) {
+	return ocs.all(context.Background(), companyID, year)
+}
+
+func (ocs *OccupancyService) all(ctx context.Context, companyID int64, year uint) (olr *OccupancyListResponse, err error) {
 	c := &Credentials{
 		Username: os.Getenv(APIUsernameContainer),
 		Password: os.Getenv(APIPasswordContainer),
@@ -25,7 +30,7 @@ func (ocs *OccupancyService) All(companyID int64, year uint) (olr *OccupancyList
 		return
 	}
 
-	res, err := ocs.client.Do(req)
+	res, err := ocs.client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
@@ -0,0 +1,241 @@
+package ns
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultOptionSchedulerInterval is how often Run checks tracked options.
+const DefaultOptionSchedulerInterval = time.Minute
+
+// OptionAction is what the scheduler does with an option close to expiry.
+type OptionAction int
+
+// Option actions.
+const (
+	OptionActionNone OptionAction = iota
+	OptionActionBook
+	OptionActionRelease
+)
+
+// OptionPolicy tells the scheduler what to do with an option and how long
+// before its expiry to do it. Before must be positive when an action is set,
+// an option can no longer be booked or released once it expired.
+type OptionPolicy struct {
+	Action OptionAction
+	Before time.Duration
+}
+
+// OptionEventKind describes what happened to a tracked option.
+type OptionEventKind int
+
+// Option event kinds.
+const (
+	OptionEventWarning OptionEventKind = iota
+	OptionEventBooked
+	OptionEventReleased
+	OptionEventExpired
+)
+
+// OptionEvent is delivered by the scheduler for a tracked option. Lead is
+// only set on warnings, Err is set when the policy action failed.
+type OptionEvent struct {
+	Kind        OptionEventKind
+	Reservation *ReservationInfo
+	ExpiresAt   time.Time
+	Lead        time.Duration
+	Err         error
+}
+
+// ErrNoOptionExpiry is returned when tracking a reservation without an option time.
+var ErrNoOptionExpiry = errors.New("reservation has no option expiry time")
+
+// ErrOptionPolicyTooLate is returned when tracking an option with a policy
+// action that would only be due once the option expired.
+var ErrOptionPolicyTooLate = errors.New("option policy must act before the option expires")
+
+type trackedOption struct {
+	reservation *ReservationInfo
+	policy      OptionPolicy
+	warned      map[time.Duration]bool
+}
+
+// OptionScheduler tracks open options and emits warnings at configured lead
+// times before they expire, applying each option's policy once its action
+// time is reached.
+type OptionScheduler struct {
+	reservations *ReservationService
+	leadTimes    []time.Duration
+	fn           func(OptionEvent)
+	mu           sync.Mutex
+	tracked      map[int64]*trackedOption
+}
+
+// NewOptionScheduler returns a scheduler that warns at each of the given lead
+// times before an option expires and delivers its events to fn. Lead times
+// must be positive.
+func NewOptionScheduler(rsrv *ReservationService, leadTimes []time.Duration, fn func(OptionEvent)) *OptionScheduler {
+	lt := append([]time.Duration(nil), leadTimes...)
+	sort.Sort(sort.Reverse(durations(lt)))
+
+	return &OptionScheduler{
+		reservations: rsrv,
+		leadTimes:    lt,
+		fn:           fn,
+		tracked:      make(map[int64]*trackedOption),
+	}
+}
+
+// Track starts tracking an option, replacing any previous policy for it.
+func (s *OptionScheduler) Track(ri *ReservationInfo, policy OptionPolicy) error {
+	if ri.OptionTill == nil {
+		return ErrNoOptionExpiry
+	}
+
+	if st := ri.State(); st != ReservationStatusOption && st != ReservationStatusWaitingOption {
+		return &ReservationStatusError{ID: ri.ID, Status: st, Operation: "track option on"}
+	}
+
+	if policy.Action != OptionActionNone && policy.Before <= 0 {
+		return ErrOptionPolicyTooLate
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tracked[ri.ID] = &trackedOption{
+		reservation: ri,
+		policy:      policy,
+		warned:      make(map[time.Duration]bool),
+	}
+
+	return nil
+}
+
+// Untrack stops tracking an option.
+func (s *OptionScheduler) Untrack(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tracked, id)
+}
+
+// Tracked returns the IDs of the options currently tracked.
+func (s *OptionScheduler) Tracked() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]int64, 0, len(s.tracked))
+	for id := range s.tracked {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// Run checks tracked options at the given interval until the context is done.
+func (s *OptionScheduler) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultOptionSchedulerInterval
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		s.Check(time.Now())
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Check emits the warnings due at the given time and applies the policies
+// of the options whose action time has been reached. Options are untracked
+// once their policy was applied or they expired.
+func (s *OptionScheduler) Check(now time.Time) {
+	var (
+		events []OptionEvent
+		due    []*trackedOption
+	)
+
+	s.mu.Lock()
+	for id, to := range s.tracked {
+		till := to.reservation.OptionTill.Time
+
+		if now.After(till) {
+			delete(s.tracked, id)
+			events = append(events, OptionEvent{Kind: OptionEventExpired, Reservation: to.reservation, ExpiresAt: till})
+			continue
+		}
+
+		// Only the closest lead time is reported when several passed
+		// since the last check.
+		var lead time.Duration
+		for _, lt := range s.leadTimes {
+			if to.warned[lt] || now.Before(till.Add(-lt)) {
+				continue
+			}
+
+			to.warned[lt] = true
+			lead = lt
+		}
+		if lead > 0 {
+			events = append(events, OptionEvent{Kind: OptionEventWarning, Reservation: to.reservation, ExpiresAt: till, Lead: lead})
+		}
+
+		if to.policy.Action != OptionActionNone && !now.Before(till.Add(-to.policy.Before)) {
+			delete(s.tracked, id)
+			due = append(due, to)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, e := range events {
+		s.fn(e)
+	}
+
+	for _, to := range due {
+		s.fn(s.apply(to))
+	}
+}
+
+func (s *OptionScheduler) apply(to *trackedOption) OptionEvent {
+	e := OptionEvent{
+		Reservation: to.reservation,
+		ExpiresAt:   to.reservation.OptionTill.Time,
+	}
+
+	var (
+		ri  *ReservationInfo
+		err error
+	)
+
+	switch to.policy.Action {
+	case OptionActionBook:
+		e.Kind = OptionEventBooked
+		ri, err = s.reservations.BookingFrom(to.reservation)
+	case OptionActionRelease:
+		e.Kind = OptionEventReleased
+		ri, err = s.reservations.Release(to.reservation)
+	}
+
+	if ri != nil {
+		e.Reservation = ri
+	}
+	e.Err = err
+
+	return e
+}
+
+type durations []time.Duration
+
+func (d durations) Len() int           { return len(d) }
+func (d durations) Less(i, j int) bool { return d[i] < d[j] }
+func (d durations) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
@@ -0,0 +1,160 @@
+package ns
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestOptionScheduler_Check(t *testing.T) {
+	till := time.Date(2021, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	var events []OptionEvent
+	s := NewOptionScheduler(nil, []time.Duration{time.Hour, 24 * time.Hour}, func(e OptionEvent) {
+		events = append(events, e)
+	})
+
+	err := s.Track(&ReservationInfo{
+		ID:                1,
+		ReservationStatus: ReservationStatusOption,
+		OptionTill:        &NausysDateTime{till},
+	}, OptionPolicy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		now  time.Time
+		kind OptionEventKind
+		lead time.Duration
+		n    int
+	}{
+		{till.Add(-48 * time.Hour), 0, 0, 0},
+		{till.Add(-23 * time.Hour), OptionEventWarning, 24 * time.Hour, 1},
+		{till.Add(-22 * time.Hour), 0, 0, 0},
+		{till.Add(-30 * time.Minute), OptionEventWarning, time.Hour, 1},
+		{till.Add(time.Minute), OptionEventExpired, 0, 1},
+	}
+
+	for _, st := range steps {
+		events = nil
+		s.Check(st.now)
+
+		if len(events) != st.n {
+			t.Fatalf("at %v expected %d events, got %d", st.now, st.n, len(events))
+		}
+
+		if st.n == 1 && (events[0].Kind != st.kind || events[0].Lead != st.lead) {
+			t.Errorf("at %v unexpected event %+v", st.now, events[0])
+		}
+	}
+
+	if len(s.Tracked()) != 0 {
+		t.Error("expired option is still tracked")
+	}
+}
+
+func TestOptionScheduler_TrackRequiresExpiry(t *testing.T) {
+	s := NewOptionScheduler(nil, nil, func(OptionEvent) {})
+
+	if err := s.Track(&ReservationInfo{ReservationStatus: ReservationStatusOption}, OptionPolicy{}); err != ErrNoOptionExpiry {
+		t.Errorf("expected %v, got %v", ErrNoOptionExpiry, err)
+	}
+}
+
+func TestOptionScheduler_TrackRejectsLatePolicy(t *testing.T) {
+	s := NewOptionScheduler(nil, nil, func(OptionEvent) {})
+
+	ri := &ReservationInfo{
+		ReservationStatus: ReservationStatusOption,
+		OptionTill:        &NausysDateTime{time.Now()},
+	}
+
+	if err := s.Track(ri, OptionPolicy{Action: OptionActionBook}); err != ErrOptionPolicyTooLate {
+		t.Errorf("expected %v, got %v", ErrOptionPolicyTooLate, err)
+	}
+
+	if err := s.Track(ri, OptionPolicy{}); err != nil {
+		t.Errorf("expected warnings only to be accepted, got %v", err)
+	}
+}
+
+func TestOptionScheduler_CheckPolicy(t *testing.T) {
+	till := time.Now().Add(48 * time.Hour)
+
+	tests := []struct {
+		name   string
+		action OptionAction
+		kind   OptionEventKind
+		status ReservationStatus
+	}{
+		{"release", OptionActionRelease, OptionEventReleased, ReservationStatusCancelled},
+		{"book", OptionActionBook, OptionEventBooked, ReservationStatusBooking},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, c := newFakeNausys(t)
+			ri := &ReservationInfo{ID: 1, ReservationStatus: ReservationStatusOption, OptionTill: &NausysDateTime{till}}
+			f.add(ri)
+
+			var events []OptionEvent
+			s := NewOptionScheduler(c.Reservation, nil, func(e OptionEvent) {
+				events = append(events, e)
+			})
+
+			if err := s.Track(ri, OptionPolicy{Action: tt.action, Before: time.Hour}); err != nil {
+				t.Fatal(err)
+			}
+
+			s.Check(till.Add(-2 * time.Hour))
+			if len(events) != 0 {
+				t.Fatalf("expected no event before the action time, got %+v", events)
+			}
+
+			s.Check(till.Add(-30 * time.Minute))
+			if len(events) != 1 || events[0].Kind != tt.kind || events[0].Err != nil {
+				t.Fatalf("unexpected events %+v", events)
+			}
+
+			if st := f.get(1).State(); st != tt.status {
+				t.Errorf("expected %s, got %s", tt.status, st)
+			}
+			if len(s.Tracked()) != 0 {
+				t.Error("option is still tracked after its policy was applied")
+			}
+		})
+	}
+}
+
+func TestOptionScheduler_CheckNausysTime(t *testing.T) {
+	var ri ReservationInfo
+	err := json.Unmarshal([]byte(`{"id":1,"reservationStatus":"OPTION","optionTill":"10.06.2021 12:00"}`), &ri)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nausys times are Croatian wall clock times, CEST in June.
+	till := time.Date(2021, 6, 10, 10, 0, 0, 0, time.UTC)
+	if !ri.OptionTill.Equal(till) {
+		t.Fatalf("expected the option to expire at %v, got %v", till, ri.OptionTill.UTC())
+	}
+
+	var events []OptionEvent
+	s := NewOptionScheduler(nil, nil, func(e OptionEvent) {
+		events = append(events, e)
+	})
+	if err := s.Track(&ri, OptionPolicy{}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Check(time.Date(2021, 6, 10, 10, 30, 0, 0, time.UTC))
+	if len(events) != 1 || events[0].Kind != OptionEventExpired {
+		t.Errorf("expected the option to be expired at 10:30 UTC, got %+v", events)
+	}
+
+	b, _ := json.Marshal(ri.OptionTill)
+	if string(b) != `"10.06.2021 12:00"` {
+		t.Errorf("expected the time to be written in Nausys time, got %s", b)
+	}
+}
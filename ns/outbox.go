@@ -0,0 +1,244 @@
+package ns
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"path/filepath"
+	"time"
+)
+
+// DefaultOutboxInterval is how often Run replays pending outbox entries.
+const DefaultOutboxInterval = time.Minute
+
+// OutboxStatus is the delivery status of an outbox entry.
+type OutboxStatus string
+
+// Outbox entry statuses.
+const (
+	OutboxStatusPending OutboxStatus = "pending"
+	OutboxStatusDone    OutboxStatus = "done"
+	OutboxStatusFailed  OutboxStatus = "failed"
+)
+
+// OutboxEntry is a booking operation waiting to be, or already, sent to
+// Nausys. Exactly one of Info and OptionBooking is set, depending on the
+// operation. Requests are stored without credentials.
+type OutboxEntry struct {
+	ID            string                `json:"id"`
+	Operation     string                `json:"operation"`
+	Info          *InfoRequest          `json:"info,omitempty"`
+	OptionBooking *OptionBookingRequest `json:"optionBooking,omitempty"`
+	Status        OutboxStatus          `json:"status"`
+	Attempts      int                   `json:"attempts"`
+	LastError     string                `json:"lastError,omitempty"`
+	Result        *ReservationInfo      `json:"result,omitempty"`
+	CreatedAt     time.Time             `json:"createdAt"`
+	UpdatedAt     time.Time             `json:"updatedAt"`
+}
+
+// Outbox persists booking operations before sending them so that they
+// survive restarts and upstream outages. Operations that cannot reach Nausys
+// stay pending and are replayed later, each entry is sent through an
+// idempotency layer keyed by its ID so replays never duplicate a reservation.
+type Outbox struct {
+	store      OutboxStore
+	idempotent *IdempotentReservations
+}
+
+// ErrOutboxStoreRequired is returned by NewOutbox when a store is missing.
+var ErrOutboxStoreRequired = errors.New("outbox and idempotency stores are required")
+
+// NewOutbox returns an outbox over the reservation service. Both stores are
+// required and the idempotency store must be as durable as the outbox store
+// for replays to be safe across restarts, see NewFileOutbox.
+func NewOutbox(rsrv *ReservationService, store OutboxStore, idem IdempotencyStore) (*Outbox, error) {
+	if store == nil || idem == nil {
+		return nil, ErrOutboxStoreRequired
+	}
+
+	return &Outbox{
+		store:      store,
+		idempotent: NewIdempotentReservations(rsrv, idem),
+	}, nil
+}
+
+// NewFileOutbox returns an outbox keeping its entries and idempotency records
+// in outbox.json and idempotency.json in the given directory.
+func NewFileOutbox(rsrv *ReservationService, dir string) (*Outbox, error) {
+	store, err := NewFileOutboxStore(filepath.Join(dir, "outbox.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	idem, err := NewFileIdempotencyStore(filepath.Join(dir, "idempotency.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOutbox(rsrv, store, idem)
+}
+
+// EnqueueInfo persists an info reservation request.
+func (o *Outbox) EnqueueInfo(ir *InfoRequest) (*OutboxEntry, error) {
+	c := *ir
+	c.Credentials = nil
+
+	return o.enqueue(&OutboxEntry{Operation: IdempotentCreateInfo, Info: &c})
+}
+
+// EnqueueOption persists an option request.
+func (o *Outbox) EnqueueOption(obr *OptionBookingRequest) (*OutboxEntry, error) {
+	c := *obr
+	c.Credentials = nil
+
+	return o.enqueue(&OutboxEntry{Operation: IdempotentCreateOption, OptionBooking: &c})
+}
+
+// EnqueueBooking persists a booking request.
+func (o *Outbox) EnqueueBooking(obr *OptionBookingRequest) (*OutboxEntry, error) {
+	c := *obr
+	c.Credentials = nil
+
+	return o.enqueue(&OutboxEntry{Operation: IdempotentCreateBooking, OptionBooking: &c})
+}
+
+// Get returns the current state of an entry, including its outcome once sent.
+func (o *Outbox) Get(id string) (*OutboxEntry, error) {
+	return o.store.Get(id)
+}
+
+func (o *Outbox) enqueue(e *OutboxEntry) (*OutboxEntry, error) {
+	id, err := newOutboxID()
+	if err != nil {
+		return nil, err
+	}
+
+	e.ID = id
+	e.Status = OutboxStatusPending
+	e.CreatedAt = time.Now()
+	e.UpdatedAt = e.CreatedAt
+
+	if err = o.store.Save(e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Replay sends the pending entries in the order they were enqueued and
+// records their outcome. Entries rejected by Nausys are marked as failed.
+// Replay stops at the first entry that fails because Nausys is unreachable,
+// leaving it and the following entries pending, and returns that error.
+func (o *Outbox) Replay(ctx context.Context) (sent []*OutboxEntry, err error) {
+	pending, err := o.store.Pending()
+	if err != nil {
+		return
+	}
+
+	for _, e := range pending {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		ri, cerr := o.send(e)
+
+		e.Attempts++
+		e.UpdatedAt = time.Now()
+
+		switch {
+		case cerr == nil:
+			e.Status = OutboxStatusDone
+			e.Result = ri
+			e.LastError = ""
+		case isOutage(cerr):
+			e.LastError = cerr.Error()
+			if err = o.store.Save(e); err != nil {
+				return
+			}
+			return sent, cerr
+		default:
+			e.Status = OutboxStatusFailed
+			e.LastError = cerr.Error()
+		}
+
+		if err = o.store.Save(e); err != nil {
+			return
+		}
+
+		sent = append(sent, e)
+	}
+
+	return
+}
+
+// Run replays pending entries at the given interval until the context is
+// done. Replay errors are passed to onErr when it is not nil.
+func (o *Outbox) Run(ctx context.Context, interval time.Duration, onErr func(error)) error {
+	if interval <= 0 {
+		interval = DefaultOutboxInterval
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		if _, err := o.Replay(ctx); err != nil && onErr != nil && ctx.Err() == nil {
+			onErr(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (o *Outbox) send(e *OutboxEntry) (*ReservationInfo, error) {
+	key := "outbox:" + e.ID
+
+	switch e.Operation {
+	case IdempotentCreateInfo:
+		c := *e.Info
+		return o.idempotent.CreateInfo(key, &c)
+	case IdempotentCreateOption:
+		c := *e.OptionBooking
+		return o.idempotent.CreateOption(key, &c)
+	case IdempotentCreateBooking:
+		c := *e.OptionBooking
+		return o.idempotent.CreateBooking(key, &c)
+	}
+
+	return nil, fmt.Errorf("unknown outbox operation %q", e.Operation)
+}
+
+// isOutage reports whether an error means Nausys could not be reached or
+// is temporarily unavailable, as opposed to rejecting the request.
+func isOutage(err error) bool {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code >= 500
+	}
+
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		return true
+	}
+
+	var ne net.Error
+	return errors.As(err, &ne)
+}
+
+func newOutboxID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,238 @@
+package ns
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// OutboxStore persists outbox entries.
+type OutboxStore interface {
+	// Save creates or replaces the entry with the same ID.
+	Save(e *OutboxEntry) error
+	// Pending returns the entries still waiting to be sent, oldest first.
+	Pending() ([]*OutboxEntry, error)
+	// Get returns the entry with the given ID, or nil when it is unknown.
+	Get(id string) (*OutboxEntry, error)
+}
+
+// FileOutboxStore is an OutboxStore keeping all entries in a single JSON
+// file, rewritten atomically on every change.
+type FileOutboxStore struct {
+	mu          sync.Mutex
+	path        string
+	entries     map[string]*OutboxEntry
+	quarantined []json.RawMessage
+}
+
+// NewFileOutboxStore opens the outbox file at path, creating it on the
+// first write if it does not exist. Entries that cannot be decoded are
+// quarantined instead of failing the whole outbox, see Quarantined.
+func NewFileOutboxStore(path string) (fs *FileOutboxStore, err error) {
+	fs = &FileOutboxStore{
+		path:    path,
+		entries: make(map[string]*OutboxEntry),
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []json.RawMessage
+	if err = json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+
+	for _, r := range raw {
+		var e OutboxEntry
+		if err := json.Unmarshal(r, &e); err != nil || e.ID == "" {
+			fs.quarantined = append(fs.quarantined, r)
+			continue
+		}
+		fs.entries[e.ID] = &e
+	}
+
+	return fs, nil
+}
+
+// Quarantined returns the entries of the file that could not be decoded.
+// They are never replayed and are kept in the file as they were.
+func (fs *FileOutboxStore) Quarantined() []json.RawMessage {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	return append([]json.RawMessage(nil), fs.quarantined...)
+}
+
+// Save creates or replaces the entry with the same ID.
+func (fs *FileOutboxStore) Save(e *OutboxEntry) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	c := *e
+	fs.entries[e.ID] = &c
+
+	return fs.flush()
+}
+
+// Pending returns the entries still waiting to be sent, oldest first.
+func (fs *FileOutboxStore) Pending() ([]*OutboxEntry, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	var pending []*OutboxEntry
+	for _, e := range fs.entries {
+		if e.Status == OutboxStatusPending {
+			c := *e
+			pending = append(pending, &c)
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+
+	return pending, nil
+}
+
+// Get returns the entry with the given ID, or nil when it is unknown.
+func (fs *FileOutboxStore) Get(id string) (*OutboxEntry, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	e, ok := fs.entries[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *e
+
+	return &c, nil
+}
+
+func (fs *FileOutboxStore) flush() error {
+	entries := make([]*OutboxEntry, 0, len(fs.entries))
+	for _, e := range fs.entries {
+		entries = append(entries, e)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+
+	out := make([]interface{}, 0, len(entries)+len(fs.quarantined))
+	for _, e := range entries {
+		out = append(out, e)
+	}
+	for _, r := range fs.quarantined {
+		out = append(out, r)
+	}
+
+	return writeJSONFile(fs.path, out)
+}
+
+// FileIdempotencyStore is an IdempotencyStore keeping all records in a
+// single JSON file, rewritten atomically on every change. It is meant to be
+// used with a FileOutboxStore so that replays stay idempotent across restarts.
+type FileIdempotencyStore struct {
+	mu      sync.Mutex
+	path    string
+	records map[string]IdempotencyRecord
+}
+
+// NewFileIdempotencyStore opens the idempotency file at path, creating it on
+// the first write if it does not exist.
+func NewFileIdempotencyStore(path string) (is *FileIdempotencyStore, err error) {
+	is = &FileIdempotencyStore{
+		path:    path,
+		records: make(map[string]IdempotencyRecord),
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return is, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []IdempotencyRecord
+	if err = json.Unmarshal(b, &records); err != nil {
+		return nil, err
+	}
+
+	for _, rec := range records {
+		is.records[rec.Key] = rec
+	}
+
+	return is, nil
+}
+
+// Get returns the record stored for the key.
+func (is *FileIdempotencyStore) Get(key string) (*IdempotencyRecord, error) {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	rec, ok := is.records[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &rec, nil
+}
+
+// Put stores the record under its key.
+func (is *FileIdempotencyStore) Put(rec *IdempotencyRecord) error {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	is.records[rec.Key] = *rec
+
+	records := make([]IdempotencyRecord, 0, len(is.records))
+	for _, r := range is.records {
+		records = append(records, r)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Key < records[j].Key
+	})
+
+	return writeJSONFile(is.path, records)
+}
+
+// writeJSONFile replaces the file at path with the JSON encoding of v,
+// through a temporary file so that readers never see a partial write.
+func writeJSONFile(path string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
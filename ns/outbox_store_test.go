@@ -0,0 +1,88 @@
+package ns
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileOutboxStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.json")
+
+	fs, err := NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	entries := []*OutboxEntry{
+		{ID: "b", Operation: IdempotentCreateBooking, Status: OutboxStatusPending, CreatedAt: now.Add(time.Second), OptionBooking: &OptionBookingRequest{ID: 1}},
+		{ID: "a", Operation: IdempotentCreateInfo, Status: OutboxStatusPending, CreatedAt: now, Info: &InfoRequest{YachtID: 2}},
+		{ID: "c", Operation: IdempotentCreateOption, Status: OutboxStatusDone, CreatedAt: now, OptionBooking: &OptionBookingRequest{ID: 3}},
+	}
+	for _, e := range entries {
+		if err := fs.Save(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reopened, err := NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := reopened.Pending()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pending) != 2 || pending[0].ID != "a" || pending[1].ID != "b" {
+		t.Fatalf("unexpected pending entries: %+v", pending)
+	}
+
+	if pending[0].Info == nil || pending[0].Info.YachtID != 2 {
+		t.Errorf("info request not persisted: %+v", pending[0])
+	}
+
+	e, err := reopened.Get("c")
+	if err != nil || e == nil || e.Status != OutboxStatusDone {
+		t.Errorf("unexpected entry c: %+v (%v)", e, err)
+	}
+}
+
+func TestFileOutboxStore_Quarantine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	err := ioutil.WriteFile(path, []byte(`[
+		{"id": "a", "operation": "createInfo", "status": "pending", "info": {"yachtID": 2}},
+		{"id": "b", "operation": "createInfo", "status": "pending", "info": {"agencyClientDiscountAmount": "7,5%"}}
+	]`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs, err := NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pending, _ := fs.Pending(); len(pending) != 1 || pending[0].ID != "a" {
+		t.Fatalf("expected entry a only to be pending, got %+v", pending)
+	}
+	if n := len(fs.Quarantined()); n != 1 {
+		t.Fatalf("expected 1 quarantined entry, got %d", n)
+	}
+
+	// Quarantined entries survive rewrites of the file.
+	if err := fs.Save(&OutboxEntry{ID: "c", Status: OutboxStatusDone}); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(reopened.Quarantined()); n != 1 {
+		t.Errorf("expected the quarantined entry to be kept, got %d", n)
+	}
+}
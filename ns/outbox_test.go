@@ -0,0 +1,195 @@
+package ns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+func TestNewOutbox_RequiresStores(t *testing.T) {
+	store, _ := NewFileOutboxStore(t.TempDir() + "/outbox.json")
+
+	if _, err := NewOutbox(nil, store, nil); err != ErrOutboxStoreRequired {
+		t.Errorf("expected %v, got %v", ErrOutboxStoreRequired, err)
+	}
+}
+
+func TestOutbox_Replay(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.add(&ReservationInfo{ID: 1, YachtID: 9, ReservationStatus: ReservationStatusInfo})
+	f.reject = func(endpoint string, _ int64) int {
+		if endpoint == "createOption" {
+			return 110
+		}
+		return 0
+	}
+	f.outage = func(endpoint string, yachtID int64) bool {
+		return endpoint == "createInfo" && yachtID == 2
+	}
+
+	o, err := NewFileOutbox(c.Reservation, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []*OutboxEntry
+	for _, enqueue := range []func() (*OutboxEntry, error){
+		func() (*OutboxEntry, error) { return o.EnqueueOption(&OptionBookingRequest{ID: 1}) },
+		func() (*OutboxEntry, error) { return o.EnqueueInfo(&InfoRequest{YachtID: 1}) },
+		func() (*OutboxEntry, error) { return o.EnqueueInfo(&InfoRequest{YachtID: 2}) },
+		func() (*OutboxEntry, error) { return o.EnqueueInfo(&InfoRequest{YachtID: 3}) },
+	} {
+		e, err := enqueue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, e)
+	}
+
+	sent, err := o.Replay(context.Background())
+	if !isOutage(err) {
+		t.Fatalf("expected the outage to stop the replay, got %v", err)
+	}
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 entries to be sent, got %d", len(sent))
+	}
+
+	want := []struct {
+		status   OutboxStatus
+		attempts int
+	}{
+		{OutboxStatusFailed, 1},
+		{OutboxStatusDone, 1},
+		{OutboxStatusPending, 1},
+		{OutboxStatusPending, 0},
+	}
+	for i, w := range want {
+		e, _ := o.Get(entries[i].ID)
+		if e.Status != w.status || e.Attempts != w.attempts {
+			t.Errorf("entry %d: expected %s after %d attempts, got %s after %d", i, w.status, w.attempts, e.Status, e.Attempts)
+		}
+	}
+
+	if e, _ := o.Get(entries[2].ID); e.LastError == "" {
+		t.Error("expected the outage to be recorded on the entry")
+	}
+	if e, _ := o.Get(entries[1].ID); e.Result == nil || e.Result.ID == 0 {
+		t.Errorf("expected the result to be recorded, got %+v", e.Result)
+	}
+
+	f.mu.Lock()
+	f.outage = nil
+	f.mu.Unlock()
+
+	if sent, err = o.Replay(context.Background()); err != nil || len(sent) != 2 {
+		t.Fatalf("expected the remaining entries to be sent, got %d (%v)", len(sent), err)
+	}
+}
+
+func TestOutbox_ReplayAfterRestart(t *testing.T) {
+	f, c := newFakeNausys(t)
+	dir := t.TempDir()
+
+	o, err := NewFileOutbox(c.Reservation, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := o.EnqueueInfo(&InfoRequest{YachtID: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The request reached Nausys but the process stopped before the entry
+	// was marked as done.
+	ri, err := o.send(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restarted, err := NewFileOutbox(c.Reservation, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := restarted.Replay(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sent) != 1 || sent[0].Status != OutboxStatusDone || sent[0].Result.ID != ri.ID {
+		t.Fatalf("expected the earlier reservation to be recorded, got %+v", sent)
+	}
+	if n := f.count("createInfo"); n != 1 {
+		t.Errorf("expected 1 info call, got %d", n)
+	}
+}
+
+func TestIsOutage(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&Error{Code: 503}, true},
+		{fmt.Errorf("wrapped: %w", &Error{Code: 500}), true},
+		{&Error{Code: 400}, false},
+		{&url.Error{Op: "Post", URL: "http://nausys", Err: errors.New("connection refused")}, true},
+		{errors.New("invalid response from provider:  (Code: 110)"), false},
+	}
+
+	for _, tt := range tests {
+		if got := isOutage(tt.err); got != tt.want {
+			t.Errorf("isOutage(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestOutbox_ReplayAmountsAfterRestart(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.onCreate = func(ri *ReservationInfo) {
+		ri.Currency = "EUR"
+		ri.ClientPrice, _ = ParseMoneyDecimal("1234.125", "", '.')
+	}
+	dir := t.TempDir()
+
+	o, err := NewFileOutbox(c.Reservation, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	discount, _ := ParseMoneyDecimal("7.125", "", '.')
+	e, err := o.EnqueueInfo(&InfoRequest{
+		YachtID:                        5,
+		AgencyClientDiscountAmount:     &discount,
+		AgencyClientDiscountAmountType: DiscountTypePercentage,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restarted, err := NewFileOutbox(c.Reservation, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := restarted.Replay(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted, err = NewFileOutbox(c.Reservation, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := restarted.Get(e.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != OutboxStatusDone || got.Info.AgencyClientDiscountAmount.Amount() != "7.125" {
+		t.Errorf("unexpected entry %+v", got)
+	}
+	if got.Result == nil || got.Result.ClientPrice.Amount() != "1234.125" {
+		t.Errorf("unexpected result %+v", got.Result)
+	}
+}
@@ -0,0 +1,94 @@
+package ns
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PaymentSummary is the payment situation of a reservation at a given time.
+// Amounts are in the reservation currency.
+type PaymentSummary struct {
+	Total       Money
+	Paid        Money
+	Outstanding Money
+	// Next is the earliest unpaid installment that is not overdue yet.
+	Next *BookingPaymentPlan
+	// Overdue lists the unpaid installments whose date has passed, oldest first.
+	Overdue []*BookingPaymentPlan
+	// PaymentLinks lists the unpaid installments with a valid online payment link.
+	PaymentLinks []*BookingPaymentPlan
+}
+
+// OnlinePaymentValidAt reports whether the installment can be paid online
+// at the given time.
+func (bpp *BookingPaymentPlan) OnlinePaymentValidAt(t time.Time) bool {
+	if bpp.Paid || bpp.OnlinePaymentLink == "" {
+		return false
+	}
+
+	return bpp.OnlinePaymentValidUTill == nil || !t.After(bpp.OnlinePaymentValidUTill.Time)
+}
+
+// OverdueAt reports whether the installment is unpaid and its date has
+// passed at the given time. An installment is due until the end of its day.
+func (bpp *BookingPaymentPlan) OverdueAt(t time.Time) bool {
+	if bpp.Paid || bpp.Date == nil {
+		return false
+	}
+
+	return bpp.Date.Time.Before(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC))
+}
+
+// PaymentSummaryAt computes the payment situation of the reservation from
+// its payment plan at the given time.
+func (ri *ReservationInfo) PaymentSummaryAt(t time.Time) (ps *PaymentSummary, err error) {
+	ps = &PaymentSummary{
+		Total:       Money{Currency: ri.Currency},
+		Paid:        Money{Currency: ri.Currency},
+		Outstanding: Money{Currency: ri.Currency},
+	}
+
+	plan := make([]*BookingPaymentPlan, 0, len(ri.PaymentPlan))
+	for _, bpp := range ri.PaymentPlan {
+		if bpp != nil {
+			plan = append(plan, bpp)
+		}
+	}
+
+	sort.SliceStable(plan, func(i, j int) bool {
+		if plan[i].Date == nil || plan[j].Date == nil {
+			return plan[j].Date == nil && plan[i].Date != nil
+		}
+		return plan[i].Date.Time.Before(plan[j].Date.Time)
+	})
+
+	for _, bpp := range plan {
+		if ps.Total, err = ps.Total.Add(bpp.Amount); err != nil {
+			return nil, fmt.Errorf("installment %d: %w", bpp.ID, err)
+		}
+
+		if bpp.Paid {
+			if ps.Paid, err = ps.Paid.Add(bpp.Amount); err != nil {
+				return nil, fmt.Errorf("installment %d: %w", bpp.ID, err)
+			}
+			continue
+		}
+
+		if ps.Outstanding, err = ps.Outstanding.Add(bpp.Amount); err != nil {
+			return nil, fmt.Errorf("installment %d: %w", bpp.ID, err)
+		}
+
+		if bpp.OverdueAt(t) {
+			ps.Overdue = append(ps.Overdue, bpp)
+		} else if ps.Next == nil {
+			ps.Next = bpp
+		}
+
+		if bpp.OnlinePaymentValidAt(t) {
+			ps.PaymentLinks = append(ps.PaymentLinks, bpp)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,107 @@
+package ns
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestReservationInfo_PaymentSummaryAt(t *testing.T) {
+	day := func(d int, m time.Month) *NausysDate {
+		return &NausysDate{time.Date(2021, m, d, 0, 0, 0, 0, time.UTC)}
+	}
+
+	ri := &ReservationInfo{
+		Currency: "EUR",
+		PaymentPlan: []*BookingPaymentPlan{
+			{ID: 4, Amount: NewMoney(20000, "EUR")},
+			{ID: 3, Date: day(1, 6), Amount: NewMoney(50000, "EUR"), OnlinePaymentLink: "https://pay/3"},
+			{ID: 1, Date: day(1, 3), Amount: NewMoney(30000, "EUR"), Paid: true, OnlinePaymentLink: "https://pay/1"},
+			{
+				ID:                      2,
+				Date:                    day(1, 4),
+				Amount:                  NewMoney(10000, "EUR"),
+				OnlinePaymentLink:       "https://pay/2",
+				OnlinePaymentValidUTill: &NausysDateTime{time.Date(2021, 4, 15, 12, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		at      time.Time
+		next    int64
+		overdue []int64
+		links   []int64
+	}{
+		{"before any due date", time.Date(2021, 3, 20, 9, 0, 0, 0, time.UTC), 2, nil, []int64{2, 3}},
+		{"due until the end of the day", time.Date(2021, 4, 1, 23, 59, 0, 0, time.UTC), 2, nil, []int64{2, 3}},
+		{"overdue the next day", time.Date(2021, 4, 2, 0, 0, 0, 0, time.UTC), 3, []int64{2}, []int64{2, 3}},
+		{"link expired", time.Date(2021, 4, 15, 12, 1, 0, 0, time.UTC), 3, []int64{2}, []int64{3}},
+		{"only undated left", time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC), 4, []int64{2, 3}, []int64{3}},
+	}
+
+	ids := func(bpps []*BookingPaymentPlan) []int64 {
+		var ids []int64
+		for _, bpp := range bpps {
+			ids = append(ids, bpp.ID)
+		}
+		return ids
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps, err := ri.PaymentSummaryAt(tt.at)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ps.Total.String() != NewMoney(110000, "EUR").String() ||
+				ps.Paid.String() != NewMoney(30000, "EUR").String() ||
+				ps.Outstanding.String() != NewMoney(80000, "EUR").String() {
+				t.Errorf("unexpected amounts: total %s, paid %s, outstanding %s", ps.Total, ps.Paid, ps.Outstanding)
+			}
+
+			if ps.Next == nil || ps.Next.ID != tt.next {
+				t.Errorf("expected installment %d next, got %+v", tt.next, ps.Next)
+			}
+
+			if got := ids(ps.Overdue); fmt.Sprint(got) != fmt.Sprint(tt.overdue) {
+				t.Errorf("expected overdue %v, got %v", tt.overdue, got)
+			}
+
+			if got := ids(ps.PaymentLinks); fmt.Sprint(got) != fmt.Sprint(tt.links) {
+				t.Errorf("expected payment links %v, got %v", tt.links, got)
+			}
+		})
+	}
+}
+
+func TestReservationInfo_PaymentSummaryAtCurrencyMismatch(t *testing.T) {
+	ri := &ReservationInfo{
+		Currency:    "EUR",
+		PaymentPlan: []*BookingPaymentPlan{{ID: 1, Amount: NewMoney(100, "USD")}},
+	}
+
+	var me *CurrencyMismatchError
+	if _, err := ri.PaymentSummaryAt(time.Now()); !errors.As(err, &me) {
+		t.Errorf("expected a currency mismatch, got %v", err)
+	}
+}
+
+func TestReservationInfo_PaymentSummaryAtNilInstallment(t *testing.T) {
+	ri := &ReservationInfo{
+		Currency:    "EUR",
+		PaymentPlan: []*BookingPaymentPlan{nil, {ID: 2, Amount: NewMoney(1000, "EUR")}, nil},
+	}
+
+	ps, err := ri.PaymentSummaryAt(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ps.Outstanding.String() != "10.00 EUR" || ps.Next == nil || ps.Next.ID != 2 {
+		t.Errorf("unexpected summary %+v", ps)
+	}
+}
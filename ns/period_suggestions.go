@@ -0,0 +1,75 @@
+package ns
+
+import (
+	"sort"
+	"time"
+)
+
+// PeriodSuggestion is a charter period following the check-in rules of a
+// yacht, close to the requested one.
+type PeriodSuggestion struct {
+	PeriodFrom time.Time
+	PeriodTo   time.Time
+	// Distance is the number of days check-in and check-out moved from the
+	// requested dates, added together.
+	Distance int
+}
+
+// SuggestPeriods returns the charter periods following the check-in rules
+// of the yacht whose check-in and check-out are each within flexDays of the
+// requested ones, closest first. Ties are broken by the smallest change of
+// duration, then by the earliest check-in. At most limit suggestions are
+// returned, all of them when limit is zero. The requested period is part of
+// the suggestions when it is valid.
+func (y *Yacht) SuggestPeriods(cp *CharterPeriod, flexDays, limit int) []*PeriodSuggestion {
+	from, to := dateOf(cp.PeriodFrom), dateOf(cp.PeriodTo)
+	nights := int(to.Sub(from).Hours() / 24)
+
+	var pss []*PeriodSuggestion
+	for df := -flexDays; df <= flexDays; df++ {
+		for dt := -flexDays; dt <= flexDays; dt++ {
+			c := *cp
+			c.PeriodFrom, c.PeriodTo = from.AddDate(0, 0, df), to.AddDate(0, 0, dt)
+			if !c.PeriodTo.After(c.PeriodFrom) || len(y.CheckPeriod(&c)) > 0 {
+				continue
+			}
+
+			pss = append(pss, &PeriodSuggestion{
+				PeriodFrom: c.PeriodFrom,
+				PeriodTo:   c.PeriodTo,
+				Distance:   abs(df) + abs(dt),
+			})
+		}
+	}
+
+	sort.SliceStable(pss, func(i, j int) bool {
+		if pss[i].Distance != pss[j].Distance {
+			return pss[i].Distance < pss[j].Distance
+		}
+
+		ci, cj := abs(pss[i].nights()-nights), abs(pss[j].nights()-nights)
+		if ci != cj {
+			return ci < cj
+		}
+
+		return pss[i].PeriodFrom.Before(pss[j].PeriodFrom)
+	})
+
+	if limit > 0 && len(pss) > limit {
+		pss = pss[:limit]
+	}
+
+	return pss
+}
+
+func (ps *PeriodSuggestion) nights() int {
+	return int(ps.PeriodTo.Sub(ps.PeriodFrom).Hours() / 24)
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+
+	return i
+}
@@ -0,0 +1,308 @@
+package ns
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Yacht price types.
+const (
+	YachtPriceTypeWeekly = "WEEKLY"
+	YachtPriceTypeDaily  = "DAILY"
+)
+
+// Price breakdown line kinds.
+const (
+	PriceLineBase     = "base"
+	PriceLineDiscount = "discount"
+	PriceLineService  = "service"
+	PriceLineExtra    = "extra"
+)
+
+// ErrNoPrice is returned when the yacht has no price for part of the
+// requested period.
+var ErrNoPrice = errors.New("no yacht price for the requested period")
+
+// PriceRequest describes the charter to price locally.
+type PriceRequest struct {
+	PeriodFrom time.Time
+	PeriodTo   time.Time
+	// LocationID is the check-in location, prices restricted to other
+	// locations are ignored. Zero matches any location.
+	LocationID int64
+	// BaseID is the check-in base, seasons and extras restricted to other
+	// bases are not applicable. Zero matches any base.
+	BaseID int64
+	// Pax is the number of people on board, used by per person extras.
+	Pax int
+	// Services are the IDs of the optional YachtService entries to include,
+	// obligatory services are always included.
+	Services []int64
+	// Equipment are the IDs of the AdditionalYachtEquipment entries to include.
+	Equipment []int64
+}
+
+// PriceLine is a single item of a price breakdown. Discounts are negative.
+type PriceLine struct {
+	Kind        string
+	ID          int64
+	Description string
+	Obligatory  bool
+	Amount      Money
+}
+
+// PriceBreakdown is the itemized local price of a charter.
+type PriceBreakdown struct {
+	Lines []*PriceLine
+	// Base is the price list price of the yacht for the period.
+	Base Money
+	// Discounts is the total of the regular discounts, as a negative amount.
+	Discounts Money
+	// Charter is the base price after discounts.
+	Charter  Money
+	Services Money
+	Extras   Money
+	Total    Money
+}
+
+// PriceDiff compares a local price with the one returned by Nausys.
+type PriceDiff struct {
+	PriceListPrice Money
+	ClientPrice    Money
+}
+
+// Matches reports whether the local and Nausys prices are equal within the
+// given tolerance.
+func (pd *PriceDiff) Matches(tolerance Money) bool {
+	for _, d := range []Money{pd.PriceListPrice, pd.ClientPrice} {
+		if c, err := d.Abs().Cmp(tolerance.WithCurrency(d.Currency)); err != nil || c > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the local prices minus the prices of a free yacht returned by
+// the availability endpoint, to verify local quotes.
+func (pb *PriceBreakdown) Diff(fy *FreeYacht) (pd *PriceDiff, err error) {
+	pd = &PriceDiff{}
+
+	if pd.PriceListPrice, err = pb.Base.Sub(fy.Price.PriceListPrice); err != nil {
+		return nil, err
+	}
+
+	if pd.ClientPrice, err = pb.Charter.Sub(fy.Price.ClientPrice); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (pb *PriceBreakdown) add(l *PriceLine) (err error) {
+	pb.Lines = append(pb.Lines, l)
+
+	switch l.Kind {
+	case PriceLineBase:
+		pb.Base, err = pb.Base.Add(l.Amount)
+	case PriceLineDiscount:
+		pb.Discounts, err = pb.Discounts.Add(l.Amount)
+	case PriceLineService:
+		pb.Services, err = pb.Services.Add(l.Amount)
+	case PriceLineExtra:
+		pb.Extras, err = pb.Extras.Add(l.Amount)
+	}
+	if err != nil {
+		return
+	}
+
+	pb.Total, err = pb.Total.Add(l.Amount)
+
+	return
+}
+
+// CalculatePrice computes the charter price of a yacht locally from its
+// season specific data: the base price for every night of the period, the
+// regular discounts, the obligatory and requested services and the requested
+// extras. The season used for discounts, services and extras is the one
+// pricing the check-in day. Obligatory services that do not apply to the
+// charter are left out, requesting an extra that does not apply is an error.
+func CalculatePrice(y *Yacht, pr *PriceRequest) (pb *PriceBreakdown, err error) {
+	from, to := dateOf(pr.PeriodFrom), dateOf(pr.PeriodTo)
+	if !to.After(from) {
+		return nil, fmt.Errorf("invalid period %s - %s", from.Format("02.01.2006"), to.Format("02.01.2006"))
+	}
+
+	pb = &PriceBreakdown{}
+
+	season, err := addBasePrice(pb, y, pr.BaseID, pr.LocationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	dr, err := ApplyDiscounts(pb.Base, season.RegularDiscounts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ad := range dr.Applied {
+		if err = pb.add(&PriceLine{Kind: PriceLineDiscount, ID: ad.Discount.DiscountedItemId, Amount: ad.Amount.Neg()}); err != nil {
+			return nil, err
+		}
+	}
+
+	pb.Charter = dr.Discounted
+
+	cc := &ChargeContext{
+		PeriodFrom:     from,
+		PeriodTo:       to,
+		Pax:            pr.Pax,
+		BaseID:         pr.BaseID,
+		PriceListPrice: pb.Base,
+		ClientPrice:    pb.Charter,
+	}
+
+	for _, ec := range EvaluateExtras(season, cc) {
+		kind, requested := PriceLineService, pr.Services
+		if ec.Kind == ExtraKindEquipment {
+			kind, requested = PriceLineExtra, pr.Equipment
+		}
+
+		if !ec.Obligatory && !containsID(requested, ec.ID) {
+			continue
+		}
+
+		if !ec.Applicable {
+			if ec.Obligatory {
+				continue
+			}
+			return nil, fmt.Errorf("%s %d does not apply: %s", ec.Kind, ec.ID, ec.Reason)
+		}
+
+		l := &PriceLine{
+			Kind:        kind,
+			ID:          ec.ID,
+			Description: ec.Description,
+			Obligatory:  ec.Obligatory,
+			Amount:      ec.Charge,
+		}
+		if err = pb.add(l); err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
+
+// addBasePrice adds a base price line for every price range covering the
+// period and returns the season pricing the check-in day.
+func addBasePrice(pb *PriceBreakdown, y *Yacht, baseID, locationID int64, from, to time.Time) (*YachtSeason, error) {
+	var (
+		season *YachtSeason
+		cur    *YachtPrice
+		nights int64
+	)
+
+	flush := func() error {
+		if cur == nil {
+			return nil
+		}
+
+		amount := cur.Price.Mul(nights)
+		desc := fmt.Sprintf("%d nights at %s per night", nights, cur.Price)
+		if cur.Type != YachtPriceTypeDaily {
+			amount = cur.Price.MulFrac(nights, 7)
+			desc = fmt.Sprintf("%d nights at %s per week", nights, cur.Price)
+		}
+
+		return pb.add(&PriceLine{Kind: PriceLineBase, ID: cur.ID, Description: desc, Amount: amount})
+	}
+
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		ys, yp := yachtPriceOn(y, baseID, locationID, d)
+		if yp == nil {
+			return nil, fmt.Errorf("%w: %s", ErrNoPrice, d.Format("02.01.2006"))
+		}
+
+		if season == nil {
+			season = ys
+		}
+
+		if yp != cur {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			cur, nights = yp, 0
+		}
+		nights++
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return season, nil
+}
+
+// yachtPriceOn returns the price of the yacht for the night of the given day
+// at the check-in base and location. Seasons and prices without a base or
+// location apply to all of them, zero IDs match any.
+func yachtPriceOn(y *Yacht, baseID, locationID int64, d time.Time) (*YachtSeason, *YachtPrice) {
+	for i := range y.SeasonSpecificData {
+		ys := &y.SeasonSpecificData[i]
+		if baseID != 0 && ys.BaseID != 0 && ys.BaseID != baseID {
+			continue
+		}
+		if locationID != 0 && ys.LocationID != 0 && ys.LocationID != locationID {
+			continue
+		}
+
+		for j := range ys.Prices {
+			yp := &ys.Prices[j]
+			if locationID != 0 && len(yp.LocationID) > 0 && !containsID(yp.LocationID, locationID) {
+				continue
+			}
+
+			if inDateRange(d, yp.DateFrom, yp.DateTo) {
+				return ys, yp
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// inDateRange reports whether the day falls within a Nausys date range,
+// both ends inclusive. Empty ends are open.
+func inDateRange(d time.Time, from, to string) bool {
+	if from != "" {
+		f, err := time.Parse("02.01.2006", from)
+		if err != nil || d.Before(f) {
+			return false
+		}
+	}
+
+	if to != "" {
+		t, err := time.Parse("02.01.2006", to)
+		if err != nil || d.After(t) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,134 @@
+package ns
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+const testPricedYacht = `{
+	"id": 1,
+	"seasonSpecificData": [{
+		"seasonId": 10,
+		"prices": [
+			{"id": 100, "dateFrom": "01.05.2021", "dateTo": "04.06.2021", "price": 1400, "currency": "EUR", "type": "WEEKLY"},
+			{"id": 101, "dateFrom": "05.06.2021", "dateTo": "30.09.2021", "price": "2.100,00", "currency": "EUR", "type": "WEEKLY"}
+		],
+		"regularDiscounts": [{"discountedItemId": 5, "amount": 10, "type": "PERCENTAGE"}],
+		"services": [
+			{"id": 200, "serviceId": 1, "price": "150", "currency": "EUR", "calculationType": "PER_BOOKING", "obligatory": true},
+			{"id": 201, "serviceId": 2, "price": "20", "currency": "EUR", "calculationType": "PER_DAY"}
+		],
+		"additionalYachtEquipment": [
+			{"id": 300, "equipmentId": 7, "price": "70", "currency": "EUR", "calculationType": "PER_WEEK"}
+		]
+	}]
+}`
+
+func TestCalculatePrice(t *testing.T) {
+	var y Yacht
+	if err := json.Unmarshal([]byte(testPricedYacht), &y); err != nil {
+		t.Fatal(err)
+	}
+
+	pb, err := CalculatePrice(&y, &PriceRequest{
+		PeriodFrom: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+		PeriodTo:   time.Date(2021, 6, 8, 0, 0, 0, 0, time.UTC),
+		Equipment:  []int64{300},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 4 nights at 1400/week and 3 nights at 2100/week.
+	checks := map[string]Money{
+		"1700.00 EUR": pb.Base,
+		"-170.00 EUR": pb.Discounts,
+		"1530.00 EUR": pb.Charter,
+		"150.00 EUR":  pb.Services,
+		"70.00 EUR":   pb.Extras,
+		"1750.00 EUR": pb.Total,
+	}
+	for want, m := range checks {
+		if m.String() != want {
+			t.Errorf("got %v, want %s", m, want)
+		}
+	}
+
+	if len(pb.Lines) != 5 {
+		t.Errorf("expected 5 lines, got %d", len(pb.Lines))
+	}
+
+	pd, err := pb.Diff(&FreeYacht{Price: YachtReservationPriceInfo{
+		PriceListPrice: NewMoney(170000, "EUR"),
+		ClientPrice:    NewMoney(153000, "EUR"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !pd.Matches(NewMoney(1, "")) {
+		t.Errorf("expected prices to match, got %+v", pd)
+	}
+}
+
+func TestCalculatePrice_NoPrice(t *testing.T) {
+	var y Yacht
+	if err := json.Unmarshal([]byte(testPricedYacht), &y); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := CalculatePrice(&y, &PriceRequest{
+		PeriodFrom: time.Date(2021, 9, 28, 0, 0, 0, 0, time.UTC),
+		PeriodTo:   time.Date(2021, 10, 5, 0, 0, 0, 0, time.UTC),
+	})
+	if err == nil {
+		t.Error("expected an error for a period without prices")
+	}
+}
+
+func TestCalculatePrice_Base(t *testing.T) {
+	var y Yacht
+	err := json.Unmarshal([]byte(`{
+		"id": 1,
+		"seasonSpecificData": [
+			{"seasonId": 10, "baseId": 1, "prices": [{"id": 100, "dateFrom": "01.05.2021", "dateTo": "30.09.2021", "price": 1400, "currency": "EUR", "type": "WEEKLY"}]},
+			{"seasonId": 10, "baseId": 2, "prices": [{"id": 200, "dateFrom": "01.05.2021", "dateTo": "30.09.2021", "price": 2100, "currency": "EUR", "type": "WEEKLY"}]}
+		]
+	}`), &y)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		baseID int64
+		want   string
+	}{
+		{0, "1400.00 EUR"},
+		{1, "1400.00 EUR"},
+		{2, "2100.00 EUR"},
+	}
+
+	for _, tt := range tests {
+		pb, err := CalculatePrice(&y, &PriceRequest{
+			PeriodFrom: time.Date(2021, 6, 5, 0, 0, 0, 0, time.UTC),
+			PeriodTo:   time.Date(2021, 6, 12, 0, 0, 0, 0, time.UTC),
+			BaseID:     tt.baseID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if pb.Base.String() != tt.want {
+			t.Errorf("base %d: got %v, want %s", tt.baseID, pb.Base, tt.want)
+		}
+	}
+
+	if _, err := CalculatePrice(&y, &PriceRequest{
+		PeriodFrom: time.Date(2021, 6, 5, 0, 0, 0, 0, time.UTC),
+		PeriodTo:   time.Date(2021, 6, 12, 0, 0, 0, 0, time.UTC),
+		BaseID:     3,
+	}); err == nil {
+		t.Error("expected an error for a base without prices")
+	}
+}
@@ -0,0 +1,275 @@
+package ns
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuoteOptions are the customer choices a quote is built for.
+type QuoteOptions struct {
+	// Pax is the number of people on board, used by per person extras.
+	Pax int
+	// BaseID is the check-in base, seasons and extras restricted to other
+	// bases are not applicable. Zero matches any base.
+	BaseID int64
+	// Services are the IDs of the optional YachtService entries to include,
+	// obligatory services are always included.
+	Services []int64
+	// Equipment are the IDs of the AdditionalYachtEquipment entries to include.
+	Equipment []int64
+	// Insured selects the deposit due when the client takes a deposit
+	// insurance.
+	Insured bool
+	// PaymentCurrency is the currency the client pays in, the yacht currency
+	// when empty.
+	PaymentCurrency string
+	// Converter converts amounts into the payment currency and extras priced
+	// in another currency than the yacht. Required only when currencies differ.
+	Converter *Converter
+	// PaymentPlans is the payment plan returned with the free yachts, see
+	// FreeYachtListResponse.PaymentPlans.
+	PaymentPlans []PaymentPlan
+}
+
+// QuoteInstallment is an installment of the payment plan of a quote, in the
+// payment currency.
+type QuoteInstallment struct {
+	Date       *NausysDate
+	Percentage int
+	Amount     Money
+}
+
+// Quote is a complete offer for a yacht and period. Amounts are in the yacht
+// currency unless stated otherwise.
+type Quote struct {
+	YachtID        int64
+	PeriodFrom     *NausysDate
+	PeriodTo       *NausysDate
+	LocationFromID int64
+	LocationToID   int64
+	Currency       string
+	// PriceListPrice is the charter price before discounts.
+	PriceListPrice Money
+	Discounts      []*Discount
+	// DiscountTotal is the total of the discounts, as a negative amount.
+	DiscountTotal Money
+	// ClientPrice is the charter price after discounts.
+	ClientPrice Money
+	// Services are the obligatory services applying to the charter and the
+	// selected optional ones.
+	Services  []*ExtraCharge
+	Equipment []*ExtraCharge
+	// ServicesTotal and EquipmentTotal are the sums of the extras above.
+	ServicesTotal  Money
+	EquipmentTotal Money
+	// Total is the client price with services and equipment.
+	Total   Money
+	Deposit Money
+	Insured bool
+	// PaymentCurrency is the currency the client pays in.
+	PaymentCurrency string
+	// TotalPaymentCurrency is the total in the payment currency.
+	TotalPaymentCurrency Money
+	PaymentPlan          []*QuoteInstallment
+
+	selectedServices []int64
+}
+
+// BuildQuote builds the offer of a free yacht returned by the availability
+// endpoint. The charter price and discounts are the ones of the free yacht,
+// services and equipment are evaluated from the season of the yacht pricing
+// the check-in day. Selecting an extra that is unknown or does not apply to
+// the charter is an error. A nil qo quotes the obligatory extras only.
+func BuildQuote(fy *FreeYacht, y *Yacht, qo *QuoteOptions) (q *Quote, err error) {
+	if qo == nil {
+		qo = &QuoteOptions{}
+	}
+
+	if fy.PeriodFrom == nil || fy.PeriodTo == nil {
+		return nil, fmt.Errorf("free yacht %d has no period", fy.YachtId)
+	}
+
+	from, to := dateOf(fy.PeriodFrom.Time), dateOf(fy.PeriodTo.Time)
+	season, _ := yachtPriceOn(y, qo.BaseID, fy.LocationFromId, from)
+	if season == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoPrice, from.Format("02.01.2006"))
+	}
+
+	p := fy.Price
+	q = &Quote{
+		YachtID:          fy.YachtId,
+		PeriodFrom:       fy.PeriodFrom,
+		PeriodTo:         fy.PeriodTo,
+		LocationFromID:   fy.LocationFromId,
+		LocationToID:     fy.LocationToId,
+		Currency:         p.Currency,
+		PriceListPrice:   p.PriceListPrice.WithCurrency(p.Currency),
+		Discounts:        p.Discounts,
+		ClientPrice:      p.ClientPrice.WithCurrency(p.Currency),
+		ServicesTotal:    Money{Currency: p.Currency},
+		EquipmentTotal:   Money{Currency: p.Currency},
+		Deposit:          p.DepositAmount.WithCurrency(p.Currency),
+		Insured:          qo.Insured,
+		PaymentCurrency:  qo.PaymentCurrency,
+		selectedServices: qo.Services,
+	}
+
+	if qo.Insured {
+		q.Deposit = p.DepositWhenInsuredAmount.WithCurrency(p.Currency)
+	}
+
+	if q.PaymentCurrency == "" {
+		q.PaymentCurrency = q.Currency
+	}
+
+	if q.DiscountTotal, err = q.ClientPrice.Sub(q.PriceListPrice); err != nil {
+		return nil, err
+	}
+
+	if err = q.addExtras(season, qo, from, to); err != nil {
+		return nil, err
+	}
+
+	if q.Total, err = q.ClientPrice.Add(q.ServicesTotal); err != nil {
+		return nil, err
+	}
+	if q.Total, err = q.Total.Add(q.EquipmentTotal); err != nil {
+		return nil, err
+	}
+
+	if q.TotalPaymentCurrency, err = convertTo(q.Total, q.PaymentCurrency, qo.Converter); err != nil {
+		return nil, err
+	}
+
+	q.PaymentPlan = splitPayments(q.TotalPaymentCurrency, qo.PaymentPlans)
+
+	return
+}
+
+// addExtras evaluates the obligatory and selected extras of the season and
+// adds the applicable ones to the quote.
+func (q *Quote) addExtras(season *YachtSeason, qo *QuoteOptions, from, to time.Time) error {
+	cc := &ChargeContext{
+		PeriodFrom:     from,
+		PeriodTo:       to,
+		Pax:            qo.Pax,
+		BaseID:         qo.BaseID,
+		PriceListPrice: q.PriceListPrice,
+		ClientPrice:    q.ClientPrice,
+	}
+
+	found := make(map[string][]int64)
+	for _, ec := range EvaluateExtras(season, cc) {
+		requested := qo.Services
+		if ec.Kind == ExtraKindEquipment {
+			requested = qo.Equipment
+		}
+
+		selected := containsID(requested, ec.ID)
+		if selected {
+			found[ec.Kind] = append(found[ec.Kind], ec.ID)
+		}
+
+		if !ec.Obligatory && !selected {
+			continue
+		}
+
+		if !ec.Applicable {
+			if ec.Obligatory {
+				continue
+			}
+			return fmt.Errorf("%s %d does not apply: %s", ec.Kind, ec.ID, ec.Reason)
+		}
+
+		charge, err := convertTo(ec.Charge, q.Currency, qo.Converter)
+		if err != nil {
+			return fmt.Errorf("%s %d: %w", ec.Kind, ec.ID, err)
+		}
+		ec.Charge = charge
+
+		if ec.Kind == ExtraKindEquipment {
+			q.Equipment = append(q.Equipment, ec)
+			q.EquipmentTotal, err = q.EquipmentTotal.Add(charge)
+		} else {
+			q.Services = append(q.Services, ec)
+			q.ServicesTotal, err = q.ServicesTotal.Add(charge)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for kind, ids := range map[string][]int64{ExtraKindService: qo.Services, ExtraKindEquipment: qo.Equipment} {
+		for _, id := range ids {
+			if !containsID(found[kind], id) {
+				return fmt.Errorf("unknown %s %d for the charter season", kind, id)
+			}
+		}
+	}
+
+	return nil
+}
+
+// convertTo converts an amount into the given currency, failing when a
+// conversion is needed and no converter is set.
+func convertTo(m Money, to string, c *Converter) (Money, error) {
+	if m.Currency == "" || m.Currency == to {
+		return m, nil
+	}
+
+	if c == nil {
+		return Money{}, &CurrencyMismatchError{A: m.Currency, B: to}
+	}
+
+	return c.Convert(m, to)
+}
+
+// splitPayments splits the total into the installments of the payment plan,
+// rounded to the minor unit of the currency. The last installment takes the
+// rounding remainder so that installments always add up to the total.
+func splitPayments(total Money, plans []PaymentPlan) []*QuoteInstallment {
+	if len(plans) == 0 {
+		return nil
+	}
+
+	qis := make([]*QuoteInstallment, 0, len(plans))
+	left := total
+	for i, pp := range plans {
+		amount := left
+		if i < len(plans)-1 {
+			amount = total.MulFrac(int64(pp.Percentage), 100).Round(CurrencyDecimals(total.Currency))
+			left, _ = left.Sub(amount)
+		}
+
+		qis = append(qis, &QuoteInstallment{Date: pp.Date, Percentage: pp.Percentage, Amount: amount})
+	}
+
+	return qis
+}
+
+// InfoRequest returns the request creating an info reservation for the
+// quote, with the selected optional services and the equipment.
+func (q *Quote) InfoRequest(client *ClientInfo) *InfoRequest {
+	ir := &InfoRequest{
+		ClientInfo: client,
+		YachtID:    q.YachtID,
+		PeriodFrom: q.PeriodFrom,
+		PeriodTo:   q.PeriodTo,
+	}
+
+	for _, ec := range q.Services {
+		if containsID(q.selectedServices, ec.ID) {
+			ir.Services = append(ir.Services, ec.ID)
+		}
+	}
+
+	for _, ec := range q.Equipment {
+		ir.Equipment = append(ir.Equipment, ec.ID)
+	}
+
+	if q.PaymentCurrency != q.Currency {
+		ir.PaymentCurrency = q.PaymentCurrency
+	}
+
+	return ir
+}
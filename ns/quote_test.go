@@ -0,0 +1,85 @@
+package ns
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testFreeYacht = `{
+	"yachtId": 1,
+	"periodFrom": "05.06.2021",
+	"periodTo": "12.06.2021",
+	"price": {
+		"priceListPrice": 2100,
+		"clientPrice": 1890,
+		"currency": "EUR",
+		"depositAmount": 2000,
+		"depositWhenInsuredAmount": 500,
+		"discounts": [{"discountedItemId": 5, "amount": 10, "type": "PERCENTAGE"}]
+	}
+}`
+
+func TestBuildQuote(t *testing.T) {
+	var (
+		y  Yacht
+		fy FreeYacht
+	)
+	if err := json.Unmarshal([]byte(testPricedYacht), &y); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(testFreeYacht), &fy); err != nil {
+		t.Fatal(err)
+	}
+
+	sr, _ := NewStaticRates("EUR", map[string]string{"USD": "1.2"})
+	q, err := BuildQuote(&fy, &y, &QuoteOptions{
+		Services:        []int64{201},
+		Equipment:       []int64{300},
+		Insured:         true,
+		PaymentCurrency: "USD",
+		Converter:       NewConverter(sr),
+		PaymentPlans:    []PaymentPlan{{Percentage: 30}, {Percentage: 70}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := map[string]Money{
+		"-210.00 EUR": q.DiscountTotal,
+		"290.00 EUR":  q.ServicesTotal,
+		"70.00 EUR":   q.EquipmentTotal,
+		"2250.00 EUR": q.Total,
+		"500.00 EUR":  q.Deposit,
+		"2700.00 USD": q.TotalPaymentCurrency,
+		"810.00 USD":  q.PaymentPlan[0].Amount,
+		"1890.00 USD": q.PaymentPlan[1].Amount,
+	}
+	for want, m := range checks {
+		if m.String() != want {
+			t.Errorf("got %v, want %s", m, want)
+		}
+	}
+
+	ir := q.InfoRequest(nil)
+	if len(ir.Services) != 1 || ir.Services[0] != 201 {
+		t.Errorf("expected the selected service only, got %v", ir.Services)
+	}
+	if len(ir.Equipment) != 1 || ir.Equipment[0] != 300 {
+		t.Errorf("expected the selected equipment, got %v", ir.Equipment)
+	}
+	if ir.PaymentCurrency != "USD" {
+		t.Errorf("expected USD payment currency, got %q", ir.PaymentCurrency)
+	}
+
+	if _, err := BuildQuote(&fy, &y, &QuoteOptions{Equipment: []int64{999}}); err == nil {
+		t.Error("expected an error for unknown equipment")
+	}
+
+	q, err = BuildQuote(&fy, &y, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.ServicesTotal.String() != "150.00 EUR" || len(q.Equipment) != 0 || q.Deposit.String() != "2000.00 EUR" {
+		t.Errorf("expected obligatory extras and the full deposit, got %v, %d equipment, %v", q.ServicesTotal, len(q.Equipment), q.Deposit)
+	}
+}
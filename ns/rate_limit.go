@@ -0,0 +1,94 @@
+package ns
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces out requests so that no more than a fixed number
+// of them are sent per second.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+	// free holds the slots given back by cancelled callers, in order.
+	free []time.Time
+}
+
+func newRateLimiter(rps int) *rateLimiter {
+	return &rateLimiter{
+		interval: time.Second / time.Duration(rps),
+	}
+}
+
+// wait blocks until the caller is allowed to send a request or the
+// context is done. A caller whose context is done gives its slot back,
+// so that it can be used by the next one.
+func (rl *rateLimiter) wait(ctx context.Context) error {
+	at := rl.reserve()
+
+	d := time.Until(at)
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		rl.release(at)
+		return ctx.Err()
+	}
+}
+
+// reserve returns the time of the earliest free slot.
+func (rl *rateLimiter) reserve() time.Time {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+
+	// Slots given back in the past were not used, they can't be taken
+	// anymore without getting too close to the following one.
+	i := sort.Search(len(rl.free), func(i int) bool { return !rl.free[i].Before(now) })
+	rl.free = rl.free[i:]
+	if len(rl.free) > 0 {
+		at := rl.free[0]
+		rl.free = rl.free[1:]
+		return at
+	}
+
+	if rl.next.Before(now) {
+		rl.next = now
+	}
+	at := rl.next
+	rl.next = rl.next.Add(rl.interval)
+
+	return at
+}
+
+// release gives back a slot returned by reserve.
+func (rl *rateLimiter) release(at time.Time) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if at.Add(rl.interval).Equal(rl.next) {
+		rl.next = at
+		// Earlier slots given back may now be the last ones as well.
+		for n := len(rl.free); n > 0 && rl.free[n-1].Add(rl.interval).Equal(rl.next); n-- {
+			rl.next = rl.free[n-1]
+			rl.free = rl.free[:n-1]
+		}
+		return
+	}
+
+	i := sort.Search(len(rl.free), func(i int) bool { return rl.free[i].After(at) })
+	rl.free = append(rl.free, time.Time{})
+	copy(rl.free[i+1:], rl.free[i:])
+	rl.free[i] = at
+}
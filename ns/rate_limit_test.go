@@ -0,0 +1,113 @@
+package ns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_SetRateLimit(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		times []time.Time
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+		w.Write([]byte(`{"status":"OK"}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	c, _ := NewClient(nil)
+	c.BaseURL, _ = url.Parse(ts.URL + "/")
+	c.SetRateLimit(20)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := c.NewAPIRequest(http.MethodPost, "ping", nil)
+			if _, err := c.Do(req); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(times) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(times))
+	}
+
+	// Requests leave at least 50ms apart; the server may see them a bit
+	// closer together.
+	if d := times[3].Sub(times[0]); d < 140*time.Millisecond {
+		t.Errorf("expected the requests to be spread over 150ms, got %s", d)
+	}
+}
+
+func TestRateLimiter_Cancel(t *testing.T) {
+	interval := 100 * time.Millisecond
+	rl := newRateLimiter(10)
+	start := time.Now()
+
+	if err := rl.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second slot is reserved first and given back after the third.
+	second, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() { errs <- rl.wait(second) }()
+	waitForReservation(t, rl, start.Add(2*interval))
+	go func() { errs <- rl.wait(context.Background()) }()
+	waitForReservation(t, rl, start.Add(3*interval))
+	cancel()
+	if err := <-errs; err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+
+	if err := rl.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > interval+interval/2 {
+		t.Errorf("expected the given back slot to be used, waited %s", d)
+	}
+
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+
+	// A cancelled last slot moves the next one back.
+	ctx, cancel := context.WithTimeout(context.Background(), interval/10)
+	defer cancel()
+	if err := rl.wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if !rl.next.Before(start.Add(4*interval)) || len(rl.free) != 0 {
+		t.Errorf("expected the cancelled slot to be given back, next at %s with %d free", rl.next.Sub(start), len(rl.free))
+	}
+}
+
+// waitForReservation waits until the limiter's next slot is at or after t.
+func waitForReservation(t *testing.T, rl *rateLimiter, at time.Time) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		rl.mu.Lock()
+		next := rl.next
+		rl.mu.Unlock()
+		if !next.Before(at) {
+			return
+		}
+	}
+	t.Fatal("timed out waiting for a reservation")
+}
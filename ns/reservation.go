@@ -21,7 +21,7 @@ type InfoRequest struct {
 	NumberOfPayments               int          `json:"numberOfPayments,omitempty"`
 	PaymentCurrency                string       `json:"paymentCurrency,omitempty"`
 	UseDepositPayment              string       `json:"useDepositPayment,omitempty"`
-	AgencyClientDiscountAmount     string       `json:"agencyClientDiscountAmount,omitempty"`
+	AgencyClientDiscountAmount     *Money       `json:"agencyClientDiscountAmount,omitempty"`
 	AgencyClientDiscountAmountType string       `json:"agencyClientDiscountAmountType,omitempty"`
 }
 
@@ -132,6 +132,11 @@ func (rsrv *ReservationService) CreateOption(obr *OptionBookingRequest) (r *Rese
 		return
 	}
 
+	err = checkForErrorResponse(res)
+	if err != nil {
+		return
+	}
+
 	if err = json.Unmarshal(res.content, &r); err != nil {
 		return
 	}
@@ -170,6 +175,117 @@ func (rsrv *ReservationService) CreateBooking(obr *OptionBookingRequest) (r *Res
 	return
 }
 
+// CancelOption releases an option reservation. When the request carries a
+// reservation ID, the reservation is looked up first and a
+// *ReservationStatusError is returned if it is not an option. Requests with
+// a UUID only are sent as they are and validated by Nausys.
+func (rsrv *ReservationService) CancelOption(obr *OptionBookingRequest) (r *ReservationInfo, err error) {
+	err = rsrv.checkStatus(obr.ID, "cancel option", ReservationStatusOption, ReservationStatusWaitingOption)
+	if err != nil {
+		return
+	}
+
+	obr.Credentials = &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/cancelOption", BookingURL)
+
+	req, err := rsrv.client.NewAPIRequest(http.MethodPost, target, obr)
+	if err != nil {
+		return
+	}
+
+	res, err := rsrv.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	err = checkForErrorResponse(res)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &r); err != nil {
+		return
+	}
+
+	return
+}
+
+// StornoBooking cancels a booking reservation. When the request carries a
+// reservation ID, the reservation is looked up first and a
+// *ReservationStatusError is returned if it is not a booking. Requests with
+// a UUID only are sent as they are and validated by Nausys.
+func (rsrv *ReservationService) StornoBooking(obr *OptionBookingRequest) (r *ReservationInfo, err error) {
+	err = rsrv.checkStatus(obr.ID, "storno booking", ReservationStatusBooking)
+	if err != nil {
+		return
+	}
+
+	obr.Credentials = &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/stornoBooking", BookingURL)
+
+	req, err := rsrv.client.NewAPIRequest(http.MethodPost, target, obr)
+	if err != nil {
+		return
+	}
+
+	res, err := rsrv.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	err = checkForErrorResponse(res)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &r); err != nil {
+		return
+	}
+
+	return
+}
+
+// checkStatus looks up a reservation by ID and fails when its status is not
+// one of the allowed ones. Reservations can only be looked up by ID, a zero
+// ID skips the check.
+func (rsrv *ReservationService) checkStatus(id int64, op string, allowed ...ReservationStatus) error {
+	if id == 0 {
+		return nil
+	}
+
+	rl, err := rsrv.GetReservation(&ReservationsRequest{
+		Reservations:          []int64{id},
+		IncludeWaitingOptions: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, ri := range rl.Reservations {
+		if ri.ID != id {
+			continue
+		}
+
+		for _, a := range allowed {
+			if ri.State() == a {
+				return nil
+			}
+		}
+
+		return &ReservationStatusError{ID: id, Status: ri.State(), Operation: op}
+	}
+
+	return fmt.Errorf("reservation %d not found", id)
+}
+
 func checkForErrorResponse(response *Response) error {
 	var errResp ErrorResponse
 	if err := json.Unmarshal(response.content, &errResp); err != nil {
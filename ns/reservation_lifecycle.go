@@ -0,0 +1,186 @@
+package ns
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ReservationStatus is the lifecycle status of a reservation.
+type ReservationStatus string
+
+// Nausys reservation statuses. ReservationStatusWaitingOption is not sent by
+// Nausys as such, it is derived from an option with WaitingForOption set.
+const (
+	ReservationStatusInfo          ReservationStatus = "INFO"
+	ReservationStatusOption        ReservationStatus = "OPTION"
+	ReservationStatusWaitingOption ReservationStatus = "WAITING_OPTION"
+	ReservationStatusBooking       ReservationStatus = "RESERVATION"
+	ReservationStatusCancelled     ReservationStatus = "STORNO"
+)
+
+// ReservationType is the type of a reservation listed in occupancy.
+type ReservationType string
+
+// Nausys occupancy reservation types.
+const (
+	ReservationTypeOption  ReservationType = "OPTION"
+	ReservationTypeBooking ReservationType = "RESERVATION"
+	ReservationTypeService ReservationType = "SERVICE"
+)
+
+// reservationTransitions lists the statuses each status can move to.
+var reservationTransitions = map[ReservationStatus][]ReservationStatus{
+	ReservationStatusInfo: {
+		ReservationStatusOption,
+		ReservationStatusWaitingOption,
+		ReservationStatusBooking,
+	},
+	ReservationStatusWaitingOption: {
+		ReservationStatusOption,
+		ReservationStatusCancelled,
+	},
+	ReservationStatusOption: {
+		ReservationStatusBooking,
+		ReservationStatusCancelled,
+	},
+	ReservationStatusBooking: {
+		ReservationStatusCancelled,
+	},
+}
+
+// ErrReservationExpired is returned when converting a reservation whose
+// option time has passed.
+var ErrReservationExpired = errors.New("reservation has expired")
+
+// ReservationStatusError reports an operation that is not allowed for the
+// current status of a reservation.
+type ReservationStatusError struct {
+	ID        int64
+	Status    ReservationStatus
+	Operation string
+}
+
+// Error function complies with the error interface.
+func (e *ReservationStatusError) Error() string {
+	return fmt.Sprintf("cannot %s reservation %d with status %q", e.Operation, e.ID, e.Status)
+}
+
+// CanTransitionTo reports whether a reservation can move from this
+// status to the next one.
+func (s ReservationStatus) CanTransitionTo(next ReservationStatus) bool {
+	for _, n := range reservationTransitions[s] {
+		if n == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Terminal reports whether no further transition is possible from this status.
+func (s ReservationStatus) Terminal() bool {
+	return len(reservationTransitions[s]) == 0
+}
+
+// State returns the lifecycle status of the reservation, taking the
+// waiting for option flag into account.
+func (ri *ReservationInfo) State() ReservationStatus {
+	if ri.ReservationStatus == ReservationStatusOption && ri.WaitingForOption {
+		return ReservationStatusWaitingOption
+	}
+
+	return ri.ReservationStatus
+}
+
+// ExpiredAt reports whether the info or option time of the reservation has
+// passed at the given time. Reservations without an option time never expire.
+func (ri *ReservationInfo) ExpiredAt(t time.Time) bool {
+	if ri.OptionTill == nil {
+		return false
+	}
+
+	return t.After(ri.OptionTill.Time)
+}
+
+// Expired reports whether the info or option time of the reservation has passed.
+func (ri *ReservationInfo) Expired() bool {
+	return ri.ExpiredAt(time.Now())
+}
+
+// CanConvertToOption reports whether an option can be created from the reservation.
+func (ri *ReservationInfo) CanConvertToOption() bool {
+	return ri.ValidateTransition(ReservationStatusOption) == nil
+}
+
+// CanConvertToBooking reports whether a booking can be created from the reservation.
+func (ri *ReservationInfo) CanConvertToBooking() bool {
+	return ri.ValidateTransition(ReservationStatusBooking) == nil
+}
+
+// ValidateTransition checks that the reservation can move to the next status,
+// returning a *ReservationStatusError when the transition is not allowed and
+// ErrReservationExpired when an info or option has lapsed.
+func (ri *ReservationInfo) ValidateTransition(next ReservationStatus) error {
+	s := ri.State()
+	if !s.CanTransitionTo(next) {
+		return &ReservationStatusError{ID: ri.ID, Status: s, Operation: fmt.Sprintf("move to %s", next)}
+	}
+
+	if next != ReservationStatusCancelled && ri.Expired() {
+		return ErrReservationExpired
+	}
+
+	return nil
+}
+
+// OptionFrom validates that the reservation can be turned into an option and
+// creates it. A waiting option is requested when waiting is set.
+func (rsrv *ReservationService) OptionFrom(ri *ReservationInfo, waiting bool) (r *ReservationInfo, err error) {
+	next := ReservationStatusOption
+	if waiting {
+		next = ReservationStatusWaitingOption
+	}
+
+	if err = ri.ValidateTransition(next); err != nil {
+		return
+	}
+
+	return rsrv.CreateOption(&OptionBookingRequest{
+		ID:                  ri.ID,
+		UUID:                ri.Uuid,
+		CreateWaitingOption: waiting,
+	})
+}
+
+// BookingFrom validates that the reservation can be turned into a booking
+// and creates it.
+func (rsrv *ReservationService) BookingFrom(ri *ReservationInfo) (r *ReservationInfo, err error) {
+	if err = ri.ValidateTransition(ReservationStatusBooking); err != nil {
+		return
+	}
+
+	return rsrv.CreateBooking(&OptionBookingRequest{
+		ID:   ri.ID,
+		UUID: ri.Uuid,
+	})
+}
+
+// Release validates that the reservation can be cancelled and cancels it,
+// releasing options and sending bookings to storno.
+func (rsrv *ReservationService) Release(ri *ReservationInfo) (r *ReservationInfo, err error) {
+	if err = ri.ValidateTransition(ReservationStatusCancelled); err != nil {
+		return
+	}
+
+	obr := &OptionBookingRequest{
+		ID:   ri.ID,
+		UUID: ri.Uuid,
+	}
+
+	if ri.State() == ReservationStatusBooking {
+		return rsrv.StornoBooking(obr)
+	}
+
+	return rsrv.CancelOption(obr)
+}
@@ -0,0 +1,71 @@
+package ns
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReservationInfo_ValidateTransition(t *testing.T) {
+	future := &NausysDateTime{time.Now().Add(24 * time.Hour)}
+	past := &NausysDateTime{time.Now().Add(-24 * time.Hour)}
+
+	tests := []struct {
+		name    string
+		ri      *ReservationInfo
+		next    ReservationStatus
+		wantErr error
+	}{
+		{
+			"info to option",
+			&ReservationInfo{ReservationStatus: ReservationStatusInfo, OptionTill: future},
+			ReservationStatusOption,
+			nil,
+		},
+		{
+			"expired info to booking",
+			&ReservationInfo{ReservationStatus: ReservationStatusInfo, OptionTill: past},
+			ReservationStatusBooking,
+			ErrReservationExpired,
+		},
+		{
+			"waiting option to booking",
+			&ReservationInfo{ReservationStatus: ReservationStatusOption, WaitingForOption: true},
+			ReservationStatusBooking,
+			&ReservationStatusError{},
+		},
+		{
+			"expired option can still be cancelled",
+			&ReservationInfo{ReservationStatus: ReservationStatusOption, OptionTill: past},
+			ReservationStatusCancelled,
+			nil,
+		},
+		{
+			"cancelled is terminal",
+			&ReservationInfo{ReservationStatus: ReservationStatusCancelled},
+			ReservationStatusOption,
+			&ReservationStatusError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ri.ValidateTransition(tt.next)
+			switch want := tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			case *ReservationStatusError:
+				var se *ReservationStatusError
+				if !errors.As(err, &se) {
+					t.Errorf("expected a status error, got %v", err)
+				}
+			default:
+				if err != want {
+					t.Errorf("expected %v, got %v", want, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package ns
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReservationService_CancelOption(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.add(&ReservationInfo{ID: 1, YachtID: 5, ReservationStatus: ReservationStatusOption})
+	f.add(&ReservationInfo{ID: 2, YachtID: 5, ReservationStatus: ReservationStatusBooking})
+
+	var se *ReservationStatusError
+	if _, err := c.Reservation.CancelOption(&OptionBookingRequest{ID: 2}); !errors.As(err, &se) || se.Status != ReservationStatusBooking {
+		t.Errorf("expected a status error for a booking, got %v", err)
+	}
+
+	if _, err := c.Reservation.CancelOption(&OptionBookingRequest{ID: 3}); err == nil {
+		t.Error("expected an error for an unknown reservation")
+	}
+
+	if n := f.count("cancelOption"); n != 0 {
+		t.Fatalf("expected no cancellation to be sent, got %d", n)
+	}
+
+	ri, err := c.Reservation.CancelOption(&OptionBookingRequest{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ri.State() != ReservationStatusCancelled || f.get(1).State() != ReservationStatusCancelled {
+		t.Errorf("expected the option to be cancelled, got %s", ri.State())
+	}
+}
+
+func TestReservationService_StornoBooking(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.add(&ReservationInfo{ID: 1, YachtID: 5, ReservationStatus: ReservationStatusOption, WaitingForOption: true})
+	f.add(&ReservationInfo{ID: 2, YachtID: 5, ReservationStatus: ReservationStatusBooking})
+
+	var se *ReservationStatusError
+	if _, err := c.Reservation.StornoBooking(&OptionBookingRequest{ID: 1}); !errors.As(err, &se) || se.Status != ReservationStatusWaitingOption {
+		t.Errorf("expected a status error for a waiting option, got %v", err)
+	}
+
+	if n := f.count("stornoBooking"); n != 0 {
+		t.Fatalf("expected no storno to be sent, got %d", n)
+	}
+
+	f.reject = func(endpoint string, _ int64) int {
+		if endpoint == "stornoBooking" {
+			return 120
+		}
+		return 0
+	}
+	if _, err := c.Reservation.StornoBooking(&OptionBookingRequest{ID: 2}); err == nil {
+		t.Error("expected a rejected storno to fail")
+	}
+
+	f.reject = nil
+	ri, err := c.Reservation.StornoBooking(&OptionBookingRequest{ID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ri.State() != ReservationStatusCancelled {
+		t.Errorf("expected the booking to be cancelled, got %s", ri.State())
+	}
+}
+
+func TestReservationService_CancelByUUID(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.add(&ReservationInfo{ID: 1, Uuid: "uuid-1", ReservationStatus: ReservationStatusOption})
+	f.add(&ReservationInfo{ID: 2, Uuid: "uuid-2", ReservationStatus: ReservationStatusBooking})
+
+	if _, err := c.Reservation.CancelOption(&OptionBookingRequest{UUID: "uuid-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Reservation.StornoBooking(&OptionBookingRequest{UUID: "uuid-2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []int64{1, 2} {
+		if st := f.get(id).State(); st != ReservationStatusCancelled {
+			t.Errorf("reservation %d: expected it to be cancelled, got %s", id, st)
+		}
+	}
+
+	// Without an ID the status is left for Nausys to check.
+	if n := f.count("reservations"); n != 0 {
+		t.Errorf("expected no lookup, got %d", n)
+	}
+
+	f.reject = func(string, int64) int { return 404 }
+	if _, err := c.Reservation.CancelOption(&OptionBookingRequest{UUID: "unknown"}); err == nil {
+		t.Error("expected the Nausys rejection to be returned")
+	}
+}
@@ -0,0 +1,205 @@
+package ns
+
+import (
+	"context"
+	"time"
+)
+
+// Default polling settings used when watching reservations.
+const (
+	DefaultWatchInitialInterval = 10 * time.Second
+	DefaultWatchMaxInterval     = 5 * time.Minute
+	DefaultWatchMultiplier      = 2
+)
+
+// WatchOptions tunes how reservations are polled by WatchFunc and Watch.
+type WatchOptions struct {
+	// InitialInterval is the delay between polls after a change was seen.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between polls while nothing changes.
+	MaxInterval time.Duration
+	// Multiplier grows the delay after each poll without changes.
+	Multiplier float64
+	// Deadline stops watching at the given time, if set.
+	Deadline time.Time
+	// Until reports whether a reservation reached a state it no longer
+	// needs to be watched in, WatchSettled is used when nil.
+	Until func(*ReservationInfo) bool
+}
+
+// ReservationEvent is delivered when a watched reservation is first seen,
+// when its status, approval or waiting for option flag changes, or when
+// polling fails, in which case only Err is set.
+type ReservationEvent struct {
+	ID       int64
+	At       time.Time
+	Previous *ReservationInfo
+	Current  *ReservationInfo
+	Err      error
+}
+
+// StatusChanged reports whether the event changed the reservation status.
+func (e *ReservationEvent) StatusChanged() bool {
+	return e.Previous != nil && e.Current != nil && e.Previous.ReservationStatus != e.Current.ReservationStatus
+}
+
+// ApprovedChanged reports whether the event changed the approval of the reservation.
+func (e *ReservationEvent) ApprovedChanged() bool {
+	return e.Previous != nil && e.Current != nil && e.Previous.Approved != e.Current.Approved
+}
+
+// WaitingForOptionChanged reports whether the event changed the waiting for
+// option flag of the reservation.
+func (e *ReservationEvent) WaitingForOptionChanged() bool {
+	return e.Previous != nil && e.Current != nil && e.Previous.WaitingForOption != e.Current.WaitingForOption
+}
+
+// WatchSettled reports whether a reservation no longer waits on Nausys: it
+// is cancelled, booked, expired or an approved option.
+func WatchSettled(ri *ReservationInfo) bool {
+	switch ri.State() {
+	case ReservationStatusCancelled, ReservationStatusBooking:
+		return true
+	case ReservationStatusOption:
+		return ri.Approved || ri.Expired()
+	case ReservationStatusWaitingOption:
+		return false
+	}
+
+	return ri.Expired()
+}
+
+// WatchFunc polls the given reservations with exponential backoff and calls
+// fn for every event, until each reservation satisfies opts.Until, the
+// deadline is reached or the context is done. Polling errors are reported
+// to fn and polling continues. It returns nil once every reservation settled
+// and the context error otherwise.
+func (rsrv *ReservationService) WatchFunc(ctx context.Context, ids []int64, opts *WatchOptions, fn func(ReservationEvent)) error {
+	o := WatchOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.InitialInterval <= 0 {
+		o.InitialInterval = DefaultWatchInitialInterval
+	}
+	if o.MaxInterval < o.InitialInterval {
+		o.MaxInterval = DefaultWatchMaxInterval
+		if o.MaxInterval < o.InitialInterval {
+			o.MaxInterval = o.InitialInterval
+		}
+	}
+	if o.Multiplier < 1 {
+		o.Multiplier = DefaultWatchMultiplier
+	}
+	if o.Until == nil {
+		o.Until = WatchSettled
+	}
+
+	if !o.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, o.Deadline)
+		defer cancel()
+	}
+
+	pending := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		pending[id] = true
+	}
+
+	last := make(map[int64]*ReservationInfo, len(ids))
+	interval := o.InitialInterval
+
+	for len(pending) > 0 {
+		changed := false
+
+		rl, err := rsrv.GetReservation(&ReservationsRequest{
+			Reservations:          pendingIDs(pending),
+			IncludeWaitingOptions: true,
+		})
+		if err != nil {
+			fn(ReservationEvent{At: time.Now(), Err: err})
+		} else {
+			for _, ri := range rl.Reservations {
+				if !pending[ri.ID] {
+					continue
+				}
+
+				prev := last[ri.ID]
+				if prev == nil || reservationChanged(prev, ri) {
+					changed = true
+					fn(ReservationEvent{ID: ri.ID, At: time.Now(), Previous: prev, Current: ri})
+				}
+
+				last[ri.ID] = ri
+				if o.Until(ri) {
+					delete(pending, ri.ID)
+				}
+			}
+		}
+
+		if len(pending) == 0 {
+			break
+		}
+
+		if changed {
+			interval = o.InitialInterval
+		} else {
+			interval = time.Duration(float64(interval) * o.Multiplier)
+			if interval > o.MaxInterval {
+				interval = o.MaxInterval
+			}
+		}
+
+		t := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+
+	return nil
+}
+
+// Watch runs WatchFunc in the background and delivers its events on the
+// returned channel. When watching stops early, a final event carrying the
+// context error is sent. The channel is closed once watching is over, it
+// must be drained until then.
+func (rsrv *ReservationService) Watch(ctx context.Context, ids []int64, opts *WatchOptions) <-chan ReservationEvent {
+	events := make(chan ReservationEvent)
+
+	go func() {
+		defer close(events)
+
+		send := func(e ReservationEvent) {
+			select {
+			case events <- e:
+			case <-ctx.Done():
+			}
+		}
+
+		if err := rsrv.WatchFunc(ctx, ids, opts, send); err != nil {
+			// The context is usually done by now, selecting on it
+			// would drop the event.
+			events <- ReservationEvent{At: time.Now(), Err: err}
+		}
+	}()
+
+	return events
+}
+
+func reservationChanged(prev, cur *ReservationInfo) bool {
+	return prev.ReservationStatus != cur.ReservationStatus ||
+		prev.Approved != cur.Approved ||
+		prev.WaitingForOption != cur.WaitingForOption
+}
+
+func pendingIDs(pending map[int64]bool) []int64 {
+	ids := make([]int64, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
@@ -0,0 +1,116 @@
+package ns
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReservationService_WatchFuncBackoff(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.add(&ReservationInfo{ID: 1, ReservationStatus: ReservationStatusOption, WaitingForOption: true})
+
+	var events int
+	err := c.Reservation.WatchFunc(context.Background(), []int64{1}, &WatchOptions{
+		InitialInterval: 5 * time.Millisecond,
+		MaxInterval:     40 * time.Millisecond,
+		Deadline:        time.Now().Add(200 * time.Millisecond),
+	}, func(ReservationEvent) { events++ })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be reached, got %v", err)
+	}
+
+	// Polls back off to 10, 20 and then 40ms: about 7 polls, instead of
+	// the 40 a fixed interval would make.
+	if n := f.count("reservations"); n < 4 || n > 10 {
+		t.Errorf("expected polls to back off, got %d polls", n)
+	}
+
+	if events != 1 {
+		t.Errorf("expected only the first sighting to be reported, got %d events", events)
+	}
+}
+
+func TestReservationService_WatchFuncUntil(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.add(&ReservationInfo{ID: 1, ReservationStatus: ReservationStatusOption})
+	f.add(&ReservationInfo{ID: 2, ReservationStatus: ReservationStatusOption})
+
+	f.onPoll = func(ri *ReservationInfo) {
+		if ri.ID == 2 {
+			ri.Approved = true
+		}
+	}
+
+	var approved []int64
+	err := c.Reservation.WatchFunc(context.Background(), []int64{1, 2}, &WatchOptions{
+		InitialInterval: time.Millisecond,
+		Until: func(ri *ReservationInfo) bool {
+			return ri.Approved || ri.ID == 1
+		},
+	}, func(e ReservationEvent) {
+		if e.Current != nil && e.Current.Approved {
+			approved = append(approved, e.ID)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(approved) != 1 || approved[0] != 2 {
+		t.Errorf("expected reservation 2 to be approved, got %v", approved)
+	}
+
+	// Both reservations settled on the first poll.
+	if n := f.count("reservations"); n != 1 {
+		t.Errorf("expected 1 poll, got %d", n)
+	}
+}
+
+func TestReservationService_WatchFuncMissing(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.add(&ReservationInfo{ID: 1, ReservationStatus: ReservationStatusBooking})
+	f.add(&ReservationInfo{ID: 2, ReservationStatus: ReservationStatusBooking})
+	f.dropFromPolls[2] = true
+
+	var seen []int64
+	err := c.Reservation.WatchFunc(context.Background(), []int64{1, 2}, &WatchOptions{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		Deadline:        time.Now().Add(20 * time.Millisecond),
+	}, func(e ReservationEvent) { seen = append(seen, e.ID) })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the missing reservation to stay pending, got %v", err)
+	}
+
+	if len(seen) != 1 || seen[0] != 1 {
+		t.Errorf("expected only reservation 1 to be reported, got %v", seen)
+	}
+
+	if n := f.count("reservations"); n < 2 {
+		t.Errorf("expected the missing reservation to be polled again, got %d polls", n)
+	}
+}
+
+func TestReservationService_Watch(t *testing.T) {
+	f, c := newFakeNausys(t)
+	f.add(&ReservationInfo{ID: 1, ReservationStatus: ReservationStatusOption, WaitingForOption: true})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var last ReservationEvent
+	n := 0
+	for e := range c.Reservation.Watch(ctx, []int64{1}, &WatchOptions{InitialInterval: time.Millisecond}) {
+		last = e
+		n++
+	}
+
+	if n != 2 {
+		t.Errorf("expected the first sighting and the final event, got %d events", n)
+	}
+	if !errors.Is(last.Err, context.DeadlineExceeded) {
+		t.Errorf("expected the final event to carry the context error, got %v", last.Err)
+	}
+}
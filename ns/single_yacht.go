@@ -1,17 +1,22 @@
 package ns
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 )
 
-// YachtsService operates over company requests.
+// YachtsService operates over yacht requests.
 type YachtsService service
 
 // Find retrieves a yacht with the yacht ID.
 func (sys *YachtsService) Find(y int) (r YachtListResponse, err error) {
+	return sys.find(context.Background(), int64(y))
+}
+
+func (sys *YachtsService) find(ctx context.Context, y int64) (r YachtListResponse, err error) {
 	cred := &Credentials{
 		Username: os.Getenv(APIUsernameContainer),
 		Password: os.Getenv(APIPasswordContainer),
@@ -24,6 +29,59 @@ func (sys *YachtsService) Find(y int) (r YachtListResponse, err error) {
 		return
 	}
 
+	res, err := sys.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &r); err != nil {
+		return
+	}
+
+	return
+}
+
+// IDsByCompany retrieves the IDs of all yachts belonging to a charter company.
+func (sys *YachtsService) IDsByCompany(companyID int64) (r YachtListResponse, err error) {
+	cred := &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/yachtList/%d", CatalogueURL, companyID)
+
+	req, err := sys.client.NewAPIRequest(http.MethodPost, target, cred)
+	if err != nil {
+		return
+	}
+
+	res, err := sys.client.Do(req)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(res.content, &r); err != nil {
+		return
+	}
+
+	return
+}
+
+// ByCompany retrieves the full yacht records of all yachts belonging to a
+// charter company.
+func (sys *YachtsService) ByCompany(companyID int64) (r YachtListResponse, err error) {
+	cred := &Credentials{
+		Username: os.Getenv(APIUsernameContainer),
+		Password: os.Getenv(APIPasswordContainer),
+	}
+
+	target := fmt.Sprintf("%s/yachts/%d", CatalogueURL, companyID)
+
+	req, err := sys.client.NewAPIRequest(http.MethodPost, target, cred)
+	if err != nil {
+		return
+	}
+
 	res, err := sys.client.Do(req)
 	if err != nil {
 		return
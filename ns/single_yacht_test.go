@@ -0,0 +1,63 @@
+package ns
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newYachtListServer(t *testing.T, path, body string) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != path {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, body)
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	c, _ := NewClient(nil)
+	c.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	return c
+}
+
+func TestYachtsService_IDsByCompany(t *testing.T) {
+	c := newYachtListServer(t, "/catalogue/v6/yachtList/7", `{"status":"OK","yachtIds":[3,1,2]}`)
+
+	yl, err := c.Yacht.IDsByCompany(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if yl.Status != "OK" || fmt.Sprint(yl.YachtIDs) != "[3 1 2]" {
+		t.Errorf("unexpected response %+v", yl)
+	}
+}
+
+func TestYachtsService_ByCompany(t *testing.T) {
+	c := newYachtListServer(t, "/catalogue/v6/yachts/7", `{"status":"OK","yachts":[
+		{"id":1,"name":"Blue","seasonSpecificData":[{"seasonId":10,"baseId":4}]},
+		{"id":2,"name":"Red"}
+	]}`)
+
+	yl, err := c.Yacht.ByCompany(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(yl.Yachts) != 2 || yl.Yachts[0].ID != 1 || yl.Yachts[1].ID != 2 {
+		t.Fatalf("unexpected yachts %+v", yl.Yachts)
+	}
+
+	if ys := yl.Yachts[0].SeasonSpecificData; len(ys) != 1 || ys[0].SeasonID != 10 || ys[0].BaseID != 4 {
+		t.Errorf("unexpected season data %+v", ys)
+	}
+}